@@ -2,12 +2,21 @@ package main
 
 import (
     "context"
+    "crypto/sha256"
+    "encoding/hex"
     "fmt"
     "os"
-    
+    "path/filepath"
+    "strings"
+    "time"
+
     "github.com/ClickHouse/ClickBOM/internal/config"
+    "github.com/ClickHouse/ClickBOM/internal/httpclient"
     "github.com/ClickHouse/ClickBOM/internal/sbom"
+    "github.com/ClickHouse/ClickBOM/internal/sbom/score"
+    "github.com/ClickHouse/ClickBOM/internal/sbom/sink"
     "github.com/ClickHouse/ClickBOM/internal/storage"
+    "github.com/ClickHouse/ClickBOM/internal/validation"
     "github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
@@ -19,17 +28,17 @@ func main() {
 
 func run() error {
     logger.Info("Starting ClickBOM GitHub Action for SBOM processing")
-    
+
+    ctx := context.Background()
+
     // Load and validate configuration
-    cfg, err := config.LoadConfig()
+    cfg, err := config.LoadConfig(ctx)
     if err != nil {
         return fmt.Errorf("configuration error: %w", err)
     }
-    
+
     logger.SetDebug(cfg.Debug)
     
-    ctx := context.Background()
-    
     // Create temp directory
     tempDir, err := os.MkdirTemp("", "clickbom-*")
     if err != nil {
@@ -37,51 +46,67 @@ func run() error {
     }
     defer os.RemoveAll(tempDir)
     
-    // Initialize S3 client
-    s3Client, err := storage.NewS3Client(ctx, cfg.AWSAccessKeyID, cfg.AWSSecretAccessKey, cfg.AWSRegion)
+    // Shared HTTP client carrying the module-scoped proxy and custom CA
+    // bundle settings, used for S3 so egress is consistent with the
+    // Mend/Wiz/DTrack/ClickHouse clients without touching process-wide
+    // proxy environment variables.
+    outboundHTTPClient, err := httpclient.NewClient(outboundHTTPConfig(cfg))
     if err != nil {
-        return fmt.Errorf("failed to create S3 client: %w", err)
+        return fmt.Errorf("failed to configure outbound HTTP client: %w", err)
     }
-    
+
+    // Initialize the object storage backend (S3, MinIO, GCS, Azure Blob, or a local directory)
+    objectStore, err := storage.NewObjectStore(ctx, cfg.StorageBackend, storage.ObjectStoreConfig{
+        AWSAccessKeyID:      cfg.AWSAccessKeyID,
+        AWSSecretAccessKey:  cfg.AWSSecretAccessKey,
+        AWSRegion:           cfg.AWSRegion,
+        AWSAuthMode:         cfg.AWSAuthMode,
+        AWSRoleARN:          cfg.AWSRoleARN,
+        AWSExternalID:       cfg.AWSExternalID,
+        AWSRoleSessionName:  cfg.AWSRoleSessionName,
+        S3EndpointURL:       cfg.S3EndpointURL,
+        HTTPClient:          outboundHTTPClient,
+        S3SSEMode:           cfg.S3SSEMode,
+        S3SSEKMSKeyID:       cfg.S3SSEKMSKeyID,
+        S3ObjectTags:        validation.SanitizeTags(cfg.S3ObjectTags),
+        S3Repository:        cfg.Repository,
+        S3GitSHA:            os.Getenv("GITHUB_SHA"),
+        S3SBOMSource:        cfg.SBOMSource,
+        GCSCredentialsFile:  cfg.GCSCredentialsFile,
+        AzureStorageAccount: cfg.AzureStorageAccount,
+        AzureSASToken:       cfg.AzureSASToken,
+        AzureSharedKey:      cfg.AzureSharedKey,
+        FileBaseDir:         cfg.FileBaseDir,
+    })
+    if err != nil {
+        return fmt.Errorf("failed to create object storage client: %w", err)
+    }
+
     if cfg.Merge {
-        return handleMergeMode(ctx, cfg, s3Client, tempDir)
+        return handleMergeMode(ctx, cfg, objectStore, tempDir)
     }
-    
-    return handleNormalMode(ctx, cfg, s3Client, tempDir)
+
+    return handleNormalMode(ctx, cfg, objectStore, tempDir)
 }
 
-func handleNormalMode(ctx context.Context, cfg *config.Config, s3Client *storage.S3Client, tempDir string) error {
+func handleNormalMode(ctx context.Context, cfg *config.Config, objectStore storage.ObjectStore, tempDir string) error {
     logger.Info("Running in NORMAL mode - processing SBOM from %s", cfg.SBOMSource)
     
     originalSBOM := filepath.Join(tempDir, "original_sbom.json")
     extractedSBOM := filepath.Join(tempDir, "extracted_sbom.json")
     processedSBOM := filepath.Join(tempDir, "processed_sbom.json")
     
-    // Download SBOM based on source
-    switch cfg.SBOMSource {
-    case "github":
-        logger.Info("Downloading SBOM from GitHub")
-        ghClient := sbom.NewGitHubClient(cfg.GitHubToken)
-        if err := ghClient.DownloadSBOM(ctx, cfg.Repository, originalSBOM); err != nil {
-            return fmt.Errorf("failed to download GitHub SBOM: %w", err)
-        }
-        
-    case "mend":
-        logger.Info("Downloading SBOM from Mend")
-        mendClient := sbom.NewMendClient(cfg)
-        if err := mendClient.RequestSBOMExport(ctx, originalSBOM); err != nil {
-            return fmt.Errorf("failed to download Mend SBOM: %w", err)
-        }
-        
-    case "wiz":
-        logger.Info("Downloading SBOM from Wiz")
-        wizClient := sbom.NewWizClient(cfg)
-        if err := wizClient.DownloadReport(ctx, originalSBOM); err != nil {
-            return fmt.Errorf("failed to download Wiz SBOM: %w", err)
-        }
-        
-    default:
-        return fmt.Errorf("unsupported SBOM source: %s", cfg.SBOMSource)
+    // Download SBOM from the configured source
+    source, err := sbom.NewFromConfig(cfg)
+    if err != nil {
+        return fmt.Errorf("failed to initialize SBOM source: %w", err)
+    }
+    if err := source.Validate(); err != nil {
+        return fmt.Errorf("invalid SBOM source configuration: %w", err)
+    }
+    logger.Info("Downloading SBOM from %s", source.Name())
+    if err := source.Fetch(ctx, originalSBOM); err != nil {
+        return fmt.Errorf("failed to download SBOM: %w", err)
     }
     
     // Extract from wrapper if needed
@@ -101,56 +126,375 @@ func handleNormalMode(ctx context.Context, cfg *config.Config, s3Client *storage
     if err := sbom.ConvertSBOM(extractedSBOM, processedSBOM, detectedFormat, targetFormat); err != nil {
         return fmt.Errorf("failed to convert SBOM: %w", err)
     }
-    
+
+    // In strict mode, validate the converted SBOM and fail the pipeline on
+    // any structural/semantic errors before it reaches downstream scanners.
+    if cfg.Strict {
+        validationReport, err := sbom.Validate(processedSBOM, sbom.ValidateOptions{})
+        if err != nil {
+            return fmt.Errorf("failed to validate SBOM: %w", err)
+        }
+        for _, issue := range validationReport.Issues {
+            logger.Warning("SBOM validation %s: %s: %s", issue.Severity, issue.Path, issue.Message)
+        }
+        if validationReport.HasErrors() {
+            return fmt.Errorf("SBOM failed strict validation with %d issue(s)", len(validationReport.Issues))
+        }
+    }
+
+    // Gate on SBOM quality before ingestion
+    report, err := score.ScoreSBOM(processedSBOM, cfg.SBOMFormat)
+    if err != nil {
+        return fmt.Errorf("failed to score SBOM: %w", err)
+    }
+
+    if cfg.ScoreReportKey != "" {
+        scoreReportFile := filepath.Join(tempDir, "score_report.json")
+        if err := score.WriteReport(report, scoreReportFile); err != nil {
+            return fmt.Errorf("failed to write score report: %w", err)
+        }
+        if err := objectStore.Upload(ctx, scoreReportFile, cfg.S3Bucket, cfg.ScoreReportKey, "json"); err != nil {
+            return fmt.Errorf("failed to upload score report: %w", err)
+        }
+    }
+
+    if cfg.MinSBOMScore > 0 && report.Overall < cfg.MinSBOMScore {
+        return fmt.Errorf("SBOM quality score %.2f is below the required threshold %.2f", report.Overall, cfg.MinSBOMScore)
+    }
+
+    uploadSBOM := processedSBOM
+
+    // Wrap in an in-toto attestation if requested
+    if cfg.InTotoWrap {
+        logger.Info("Wrapping SBOM in in-toto attestation before upload")
+        attestedSBOM := filepath.Join(tempDir, "attested_sbom.json")
+        predicateType := inTotoPredicateType(targetFormat)
+        digest := sha256Hex(processedSBOM)
+        if err := sbom.WrapInTotoStatement(ctx, processedSBOM, attestedSBOM, predicateType, cfg.InTotoSubjectURI, digest, cfg.CosignKeyPath); err != nil {
+            return fmt.Errorf("failed to wrap SBOM in in-toto attestation: %w", err)
+        }
+        uploadSBOM = attestedSBOM
+    }
+
     // Upload to S3
-    if err := s3Client.Upload(ctx, processedSBOM, cfg.S3Bucket, cfg.S3Key, cfg.SBOMFormat); err != nil {
+    if err := objectStore.Upload(ctx, uploadSBOM, cfg.S3Bucket, cfg.S3Key, cfg.SBOMFormat); err != nil {
         return fmt.Errorf("failed to upload to S3: %w", err)
     }
-    
+
+    // Archive a compressed, timestamped copy of the SBOM for history
+    if cfg.ArchiveEnabled {
+        archiveManager := storage.NewArchiveManager(objectStore)
+        retention := time.Duration(cfg.ArchiveRetentionDays) * 24 * time.Hour
+        archiveOpts := storage.ArchiveOptions{
+            OriginalKey:  cfg.S3Key,
+            Format:       cfg.SBOMFormat,
+            QualityScore: report.Overall,
+            Source:       cfg.SBOMSource,
+            Repo:         cfg.Repository,
+            CommitSHA:    os.Getenv("GITHUB_SHA"),
+            Compression:  cfg.ArchiveCompression,
+        }
+        if err := archiveManager.Archive(ctx, processedSBOM, cfg.S3Bucket, cfg.ArchivePrefix, cfg.SBOMFormat, retention, cfg.ArchiveRetentionCount, archiveOpts); err != nil {
+            logger.Warning("Failed to archive SBOM: %v", err)
+        }
+    }
+
+    // Maintain a queryable, count-retained snapshot history of the SBOM,
+    // separate from the age- and/or count-retained archive above
+    if cfg.SnapshotEnabled {
+        snapshotManager := storage.NewSnapshotManager(objectStore)
+        gitSHA := os.Getenv("GITHUB_SHA")
+        if err := snapshotManager.UploadSnapshot(ctx, processedSBOM, cfg.S3Bucket, cfg.SnapshotPrefix, cfg.SBOMFormat, cfg.SBOMCompression, cfg.Repository, gitSHA); err != nil {
+            logger.Warning("Failed to upload SBOM snapshot: %v", err)
+        } else if err := snapshotManager.PruneSnapshots(ctx, cfg.S3Bucket, cfg.SnapshotPrefix, cfg.SBOMRetention); err != nil {
+            logger.Warning("Failed to prune SBOM snapshots: %v", err)
+        }
+    }
+
+    // Push the processed SBOM back to Dependency-Track if requested
+    if cfg.DTrackUpload {
+        dtrackClient, err := sbom.NewDTrackClient(cfg)
+        if err != nil {
+            return fmt.Errorf("failed to create Dependency-Track client: %w", err)
+        }
+        if err := dtrackClient.UploadSBOM(ctx, processedSBOM); err != nil {
+            return fmt.Errorf("failed to upload SBOM to Dependency-Track: %w", err)
+        }
+        if err := dtrackClient.TagProject(ctx, cfg.Repository, os.Getenv("GITHUB_SHA"), cfg.SBOMFormat); err != nil {
+            logger.Warning("Failed to tag Dependency-Track project: %v", err)
+        }
+    }
+
     logger.Success("SBOM processing completed successfully!")
     logger.Info("SBOM available at: s3://%s/%s", cfg.S3Bucket, cfg.S3Key)
     
     // ClickHouse operations
     if cfg.ClickHouseURL != "" {
-        if err := handleClickHouse(ctx, cfg, processedSBOM); err != nil {
+        if err := handleClickHouse(ctx, cfg, objectStore, processedSBOM, report); err != nil {
             return fmt.Errorf("ClickHouse error: %w", err)
         }
     }
-    
+
+    // Write to any additionally configured sinks (file, stdout, a second
+    // object-store copy, an OCI registry, or ClickHouse), on top of the S3
+    // upload and ClickHouse steps above.
+    if cfg.Sinks != "" {
+        if err := writeToSinks(ctx, cfg, objectStore, uploadSBOM, processedSBOM); err != nil {
+            return fmt.Errorf("failed to write to sinks: %w", err)
+        }
+    }
+
     return nil
 }
 
-func handleMergeMode(ctx context.Context, cfg *config.Config, s3Client *storage.S3Client, tempDir string) error {
-    logger.Info("Running in MERGE mode - merging all CycloneDX SBOMs from S3")
-    
-    // Implementation for merge mode...
-    // This would involve downloading all SBOMs from S3, merging them, and uploading
-    
+func writeToSinks(ctx context.Context, cfg *config.Config, objectStore storage.ObjectStore, rawSBOM, processedSBOM string) error {
+    sinks, err := sink.BuildSinks(cfg, objectStore, generateTableName(cfg))
+    if err != nil {
+        return fmt.Errorf("failed to configure sinks: %w", err)
+    }
+
+    doc, err := sbom.ParseDocument(processedSBOM)
+    if err != nil {
+        return fmt.Errorf("failed to parse SBOM for sinks: %w", err)
+    }
+
+    rawFile, err := os.Open(rawSBOM)
+    if err != nil {
+        return fmt.Errorf("failed to open SBOM for sinks: %w", err)
+    }
+    defer rawFile.Close()
+
+    return sink.NewMultiSink(sinks...).Write(ctx, doc, rawFile)
+}
+
+func handleMergeMode(ctx context.Context, cfg *config.Config, objectStore storage.ObjectStore, tempDir string) error {
+    logger.Info("Running in MERGE mode - merging SBOMs from S3 (strategy=%s)", cfg.MergeStrategy)
+
+    keys, err := objectStore.List(ctx, cfg.S3Bucket, "")
+    if err != nil {
+        return fmt.Errorf("failed to list SBOMs to merge: %w", err)
+    }
+    keys = filterMergeKeys(keys, cfg.Include, cfg.Exclude)
+    if len(keys) == 0 {
+        return fmt.Errorf("no SBOMs matched INCLUDE/EXCLUDE under s3://%s", cfg.S3Bucket)
+    }
+    logger.Info("Merging %d SBOMs: %s", len(keys), strings.Join(keys, ", "))
+
+    var inputFiles []string
+    for i, key := range keys {
+        localFile := filepath.Join(tempDir, fmt.Sprintf("merge-input-%d%s", i, filepath.Ext(key)))
+        if err := objectStore.Download(ctx, cfg.S3Bucket, key, localFile); err != nil {
+            return fmt.Errorf("failed to download %s: %w", key, err)
+        }
+        inputFiles = append(inputFiles, localFile)
+    }
+
+    // Optionally merge in historical archives on top of the live objects
+    // listed above.
+    if cfg.MergeIncludeArchives {
+        archiveManager := storage.NewArchiveManager(objectStore)
+        archives, err := archiveManager.List(ctx, cfg.S3Bucket, cfg.ArchivePrefix)
+        if err != nil {
+            return fmt.Errorf("failed to list archives to merge: %w", err)
+        }
+        logger.Info("Merging in %d archived SBOM(s) from s3://%s/%s", len(archives), cfg.S3Bucket, cfg.ArchivePrefix)
+        for i, meta := range archives {
+            localFile := filepath.Join(tempDir, fmt.Sprintf("merge-archive-%d%s", i, filepath.Ext(meta.OriginalKey)))
+            if err := archiveManager.Restore(ctx, cfg.S3Bucket, meta, localFile); err != nil {
+                return fmt.Errorf("failed to restore archive %s: %w", meta.ArchiveKey, err)
+            }
+            inputFiles = append(inputFiles, localFile)
+        }
+    }
+
+    mergedFile := filepath.Join(tempDir, "merged_sbom.json")
+    targetFormat := sbom.Format(cfg.SBOMFormat)
+    if err := sbom.Merge(inputFiles, mergedFile, sbom.MergeOptions{
+        Strategy:     sbom.MergeStrategy(cfg.MergeStrategy),
+        TargetFormat: targetFormat,
+    }); err != nil {
+        return fmt.Errorf("failed to merge SBOMs: %w", err)
+    }
+
+    if err := objectStore.Upload(ctx, mergedFile, cfg.S3Bucket, cfg.S3Key, cfg.SBOMFormat); err != nil {
+        return fmt.Errorf("failed to upload merged SBOM: %w", err)
+    }
+
+    logger.Success("Merged SBOM uploaded to s3://%s/%s", cfg.S3Bucket, cfg.S3Key)
     return nil
 }
 
-func handleClickHouse(ctx context.Context, cfg *config.Config, sbomFile string) error {
+// filterMergeKeys narrows keys to those matching any comma-separated glob
+// in include (all keys, if include is empty) and none of the globs in
+// exclude.
+func filterMergeKeys(keys []string, include, exclude string) []string {
+    includePatterns := splitPatterns(include)
+    excludePatterns := splitPatterns(exclude)
+
+    var matched []string
+    for _, key := range keys {
+        if len(includePatterns) > 0 && !matchesAny(key, includePatterns) {
+            continue
+        }
+        if matchesAny(key, excludePatterns) {
+            continue
+        }
+        matched = append(matched, key)
+    }
+    return matched
+}
+
+func splitPatterns(patterns string) []string {
+    if patterns == "" {
+        return nil
+    }
+    return strings.Split(patterns, ",")
+}
+
+func matchesAny(key string, patterns []string) bool {
+    for _, pattern := range patterns {
+        if ok, err := filepath.Match(pattern, key); err == nil && ok {
+            return true
+        }
+    }
+    return false
+}
+
+func handleClickHouse(ctx context.Context, cfg *config.Config, objectStore storage.ObjectStore, sbomFile string, scoreReport *score.Report) error {
     logger.Info("Starting ClickHouse operations")
-    
+
+    meta := storage.ScanMetadata{
+        Source:     cfg.SBOMSource,
+        Repository: cfg.Repository,
+        CommitSHA:  os.Getenv("GITHUB_SHA"),
+    }
+    tableName := generateTableName(cfg)
+
+    // ComponentsBackend != "clickhouse" routes components through the
+    // generic storage.Store interface instead of ClickHouse's richer,
+    // SBOM-aware InsertSBOMData (which also handles verification and
+    // vulnerability enrichment - those stay ClickHouse-specific for now).
+    if cfg.ComponentsBackend != "" && cfg.ComponentsBackend != "clickhouse" {
+        return handleComponentsStore(ctx, cfg, objectStore, tableName, sbomFile, meta)
+    }
+
     chClient, err := storage.NewClickHouseClient(cfg)
     if err != nil {
         return err
     }
-    
-    tableName := generateTableName(cfg)
-    
+    defer chClient.Close()
+
     if err := chClient.SetupTable(ctx, tableName); err != nil {
         return fmt.Errorf("failed to setup table: %w", err)
     }
-    
-    if err := chClient.InsertSBOMData(ctx, sbomFile, tableName, cfg.SBOMFormat); err != nil {
+
+    if err := chClient.InsertSBOMData(ctx, sbomFile, tableName, cfg.SBOMFormat, meta); err != nil {
         return fmt.Errorf("failed to insert data: %w", err)
     }
-    
+
+    if scoreReport != nil {
+        scoresTable := tableName + "_scores"
+        if err := chClient.SetupScoresTable(ctx, scoresTable); err != nil {
+            return fmt.Errorf("failed to setup scores table: %w", err)
+        }
+        if err := chClient.InsertScoreData(ctx, scoreReport, scoresTable, meta.Source); err != nil {
+            return fmt.Errorf("failed to insert score data: %w", err)
+        }
+    }
+
+    if cfg.ScanRetentionDays > 0 {
+        retention := time.Duration(cfg.ScanRetentionDays) * 24 * time.Hour
+        if err := chClient.PruneOlderThan(ctx, tableName, retention); err != nil {
+            logger.Warning("Failed to set ClickHouse retention TTL: %v", err)
+        }
+    }
+
     logger.Success("ClickHouse operations completed successfully!")
     return nil
 }
 
+// handleComponentsStore ingests sbomFile's components into tableName via
+// the generic storage.Store abstraction, for backends other than
+// ClickHouse (cfg.ComponentsBackend).
+func handleComponentsStore(ctx context.Context, cfg *config.Config, objectStore storage.ObjectStore, tableName, sbomFile string, meta storage.ScanMetadata) error {
+    store, err := storage.NewStore(ctx, cfg.ComponentsBackend, cfg, objectStore)
+    if err != nil {
+        return fmt.Errorf("failed to create %s components store: %w", cfg.ComponentsBackend, err)
+    }
+    defer store.Close()
+
+    if err := store.SetupTable(ctx, tableName); err != nil {
+        return fmt.Errorf("failed to setup table: %w", err)
+    }
+
+    data, err := os.ReadFile(sbomFile)
+    if err != nil {
+        return fmt.Errorf("failed to read SBOM file: %w", err)
+    }
+    digest := fmt.Sprintf("%x", sha256.Sum256(data))
+
+    doc, err := sbom.ParseDocument(sbomFile)
+    if err != nil {
+        return fmt.Errorf("failed to parse SBOM: %w", err)
+    }
+    if len(doc.Components) == 0 {
+        logger.Warning("No components found in SBOM")
+        return nil
+    }
+
+    run := storage.RunMetadata{ScanMetadata: meta, SBOMDigest: digest}
+
+    components := make(chan storage.Component)
+    errCh := make(chan error, 1)
+    go func() {
+        errCh <- store.InsertComponents(ctx, tableName, run, components)
+    }()
+
+    for _, comp := range doc.Components {
+        components <- storage.ComponentFromModel(comp)
+    }
+    close(components)
+
+    if err := <-errCh; err != nil {
+        return fmt.Errorf("failed to insert components: %w", err)
+    }
+
+    logger.Success("%s components store operations completed successfully!", cfg.ComponentsBackend)
+    return nil
+}
+
+// outboundHTTPConfig builds the shared HTTP client configuration used for
+// S3, scoped to this module's proxy and CA bundle settings only.
+func outboundHTTPConfig(cfg *config.Config) httpclient.Config {
+    httpCfg := httpclient.DefaultConfig()
+    httpCfg.ProxyURL = cfg.HTTPProxyURL
+    httpCfg.NoProxy = cfg.HTTPNoProxy
+    httpCfg.CABundlePath = cfg.CustomCABundle
+    if cfg.HTTPMaxRetries > 0 {
+        httpCfg.MaxRetries = cfg.HTTPMaxRetries
+    }
+    return httpCfg
+}
+
+func inTotoPredicateType(format sbom.Format) string {
+    switch format {
+    case sbom.FormatSPDXJSON:
+        return "https://spdx.dev/Document"
+    default:
+        return "https://cyclonedx.org/bom"
+    }
+}
+
+func sha256Hex(filename string) string {
+    data, err := os.ReadFile(filename)
+    if err != nil {
+        logger.Warning("Failed to read %s for digest: %v", filename, err)
+        return ""
+    }
+    sum := sha256.Sum256(data)
+    return hex.EncodeToString(sum[:])
+}
+
 func generateTableName(cfg *config.Config) string {
     switch cfg.SBOMSource {
     case "github":
@@ -163,6 +507,8 @@ func generateTableName(cfg *config.Config) string {
         return fmt.Sprintf("mend_%s", strings.ReplaceAll(uuid, "-", "_"))
     case "wiz":
         return fmt.Sprintf("wiz_%s", strings.ReplaceAll(cfg.WizReportID, "-", "_"))
+    case "dtrack":
+        return fmt.Sprintf("dtrack_%s", strings.ReplaceAll(cfg.DTrackProjectUUID, "-", "_"))
     default:
         return "sbom_data"
     }
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -33,6 +34,12 @@ type Config struct {
 	WizClientSecret string
 	WizReportID     string
 
+	// Dependency-Track
+	DTrackBaseURL     string
+	DTrackAPIKey      string
+	DTrackProjectUUID string
+	DTrackUpload      bool
+
 	// AWS
 	AWSAccessKeyID     string
 	AWSSecretAccessKey string
@@ -40,6 +47,35 @@ type Config struct {
 	S3Bucket           string
 	S3Key              string
 
+	// AWSAuthMode selects how S3 credentials are obtained: "static"
+	// (default), "default-chain", "irsa", "assume-role", or "web-identity".
+	AWSAuthMode        string
+	AWSRoleARN         string
+	AWSExternalID      string
+	AWSRoleSessionName string
+
+	// Object storage backend selection
+	StorageBackend string // "s3", "minio", "gcs", "azblob", "file"
+
+	// S3-compatible endpoint override, used by the "minio" backend
+	S3EndpointURL string
+
+	// S3 server-side encryption and object tagging
+	S3SSEMode     string // "", "none", "AES256", or "aws:kms"
+	S3SSEKMSKeyID string
+	S3ObjectTags  string // comma-separated "key=value" pairs, cleaned by Sanitize
+
+	// GCS
+	GCSCredentialsFile string
+
+	// Azure Blob
+	AzureStorageAccount string
+	AzureSASToken       string
+	AzureSharedKey      string
+
+	// Local filesystem backend
+	FileBaseDir string
+
 	// ClickHouse
 	ClickHouseURL      string
 	ClickHouseDatabase string
@@ -47,17 +83,146 @@ type Config struct {
 	ClickHousePassword string
 	TruncateTable      bool
 
+	// ForceReingest bypasses InsertSBOMData's sbom_digest dedup check,
+	// re-inserting an SBOM that was already ingested.
+	ForceReingest bool
+	// ScanRetentionDays, when > 0, sets a TTL on the ClickHouse components
+	// and scan_runs tables so PruneOlderThan removes rows older than this
+	// many days.
+	ScanRetentionDays int
+
+	// ClickHouseTransport selects how InsertSBOMData talks to ClickHouse:
+	// "http" (legacy TSV over HTTP, the default) or "native" (batched
+	// inserts over the native protocol via clickhouse-go/v2).
+	ClickHouseTransport     string
+	ClickHouseNativeAddr    string // host:port, required when transport is "native"
+	ClickHouseTLS           bool
+	ClickHouseCompression   string // "", "lz4", or "none"
+	ClickHouseBatchSize     int
+	ClickHouseInsertRetries int
+
+	// ComponentsBackend selects the storage.Store implementation
+	// InsertSBOMData's components are written to: "clickhouse" (default),
+	// "postgres", "bigquery", or "s3parquet". Named separately from
+	// StorageBackend, which selects the object-store backend the raw SBOM
+	// file itself is uploaded to.
+	ComponentsBackend string
+
+	// Postgres (ComponentsBackend = "postgres")
+	PostgresDSN string
+
+	// BigQuery (ComponentsBackend = "bigquery")
+	BigQueryProjectID string
+	BigQueryDataset   string
+
+	// S3 Parquet (ComponentsBackend = "s3parquet"): date- and run-partitioned
+	// Parquet files written under s3://S3Bucket/ParquetPrefix/date=YYYY-MM-DD/run=<digest>.parquet
+	ParquetPrefix string
+
 	// General
 	SBOMSource string // "github", "mend", "wiz"
 	SBOMFormat string // "cyclonedx", "spdxjson"
 	Merge      bool
-	Include    string
-	Exclude    string
-	Debug      bool
+	// MergeStrategy selects how sbom.Merge reconciles components that
+	// key-match across the SBOMs being merged: "union" (default, the
+	// fields all keep), "intersection" (only components common to every
+	// input), or "override" (later inputs' fields win on a match).
+	MergeStrategy string
+	Include       string
+	Exclude       string
+	Debug         bool
+	// Strict, when true, runs sbom.Validate on the converted SBOM and
+	// fails the pipeline if it reports any errors, rather than just
+	// warnings.
+	Strict bool
+
+	// In-toto attestation
+	InTotoWrap       bool
+	InTotoSubjectURI string
+	CosignKeyPath    string
+
+	// SBOM cryptographic verification, performed by pkg/attest before
+	// InsertSBOMData reads the file. VerifySBOM is "" (disabled),
+	// "optional" (verify if a signature/attestation is configured, but
+	// ingest unverified SBOMs anyway), or "strict" (fail ingestion unless
+	// verification succeeds).
+	VerifySBOM           string
+	SBOMSignaturePath    string // detached cosign signature over the SBOM
+	SBOMCertificatePath  string // signing certificate for keyless verification
+	SBOMAttestationPath  string // in-toto DSSE attestation, checked instead of a detached signature
+	CosignPublicKeyPath  string // static cosign public key, for key-based verification
+	CosignCertIdentities string // comma-separated allow-list of signer identities, trailing "*" matches as a prefix
+	CosignCertOIDCIssuer string // expected OIDC issuer for keyless verification
+	RekorURL             string // override cosign's default Rekor transparency log endpoint
+
+	// Vulnerability enrichment (pkg/enrich): joins SBOM components against
+	// OSV.dev, or an offline OSV export, before insertion.
+	VulnEnrichment        bool
+	OSVEndpoint           string
+	VulnEnrichConcurrency int
+	VulnCacheDir          string
+	VulnCacheTTLHours     int
+	OSVOfflineZipPath     string
+
+	// SBOM quality scoring
+	MinSBOMScore   float64
+	ScoreReportKey string
+
+	// SBOM archival
+	ArchiveEnabled        bool
+	ArchivePrefix         string
+	ArchiveRetentionDays  int
+	ArchiveRetentionCount int
+	// ArchiveCompression selects the archive's compression format:
+	// "gzip" (default), "zip", or "zstd". "zstd" is accepted here but not
+	// yet implemented by storage.ArchiveManager.
+	ArchiveCompression string
+	// MergeIncludeArchives, when true, has merge mode restore and merge in
+	// historical archives (via ArchiveManager.List/Restore) under
+	// ArchivePrefix, in addition to the live objects under S3Bucket.
+	MergeIncludeArchives bool
+
+	// SBOM snapshot history: a time-partitioned, retention-pruned copy of
+	// every upload under SnapshotPrefix, distinct from the archive above —
+	// archives are pruned by age and/or count, snapshots by count only; both
+	// support gzip or zip and carry a JSON metadata sidecar.
+	SnapshotEnabled bool
+	SnapshotPrefix  string
+	SBOMRetention   int
+	SBOMCompression string // "", "gzip", or "zip"
+
+	// Outbound HTTP transport
+	HTTPProxyURL   string
+	HTTPMaxRetries int
+	// HTTPNoProxy and CustomCABundle are scoped to this module's own HTTP
+	// clients (S3, ClickHouse, Mend/Wiz/DTrack) — they are never exported
+	// into the process environment, so other steps in the same CI runner
+	// are unaffected.
+	HTTPNoProxy    string
+	CustomCABundle string
+
+	// Output sinks: a comma-separated list of "file", "stdout", an
+	// object-store backend name ("s3"/"gcs"/"azblob"), "oci", and/or
+	// "clickhouse". Additive to the default S3 upload and ClickHouse
+	// steps below; empty means no extra sinks are written.
+	Sinks        string
+	SinkFilePath string
+
+	// OCI registry sink
+	OCIRegistry   string
+	OCIRepository string
+	OCITag        string
+	OCIUsername   string
+	OCIPassword   string
+
+	// Secret resolution: credential fields may hold a secret:// reference
+	// resolved through one of these backends instead of a literal value.
+	VaultAddress string
+	VaultToken   string
 }
 
 // LoadConfig loads configuration from environment variables.
-func LoadConfig() (*Config, error) {
+func LoadConfig(ctx context.Context) (*Config, error) {
 	cfg := &Config{
 		// AWS (required)
 		AWSAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
@@ -66,6 +231,30 @@ func LoadConfig() (*Config, error) {
 		S3Bucket:           os.Getenv("S3_BUCKET"),
 		S3Key:              getEnvOrDefault("S3_KEY", "sbom.json"),
 
+		AWSAuthMode:        getEnvOrDefault("AWS_AUTH_MODE", "static"),
+		AWSRoleARN:         os.Getenv("AWS_ROLE_ARN"),
+		AWSExternalID:      os.Getenv("AWS_EXTERNAL_ID"),
+		AWSRoleSessionName: getEnvOrDefault("AWS_ROLE_SESSION_NAME", "clickbom"),
+
+		// Object storage backend selection
+		StorageBackend: getEnvOrDefault("STORAGE_BACKEND", "s3"),
+		S3EndpointURL:  os.Getenv("S3_ENDPOINT_URL"),
+
+		S3SSEMode:     os.Getenv("S3_SSE_MODE"),
+		S3SSEKMSKeyID: os.Getenv("S3_SSE_KMS_KEY_ID"),
+		S3ObjectTags:  os.Getenv("S3_OBJECT_TAGS"),
+
+		// GCS
+		GCSCredentialsFile: os.Getenv("GCS_CREDENTIALS_FILE"),
+
+		// Azure Blob
+		AzureStorageAccount: os.Getenv("AZURE_STORAGE_ACCOUNT"),
+		AzureSASToken:       os.Getenv("AZURE_SAS_TOKEN"),
+		AzureSharedKey:      os.Getenv("AZURE_SHARED_KEY"),
+
+		// Local filesystem backend
+		FileBaseDir: os.Getenv("FILE_BASE_DIR"),
+
 		// GitHub
 		GitHubToken: os.Getenv("GITHUB_TOKEN"),
 		Repository:  os.Getenv("REPOSITORY"),
@@ -89,20 +278,110 @@ func LoadConfig() (*Config, error) {
 		WizClientSecret: os.Getenv("WIZ_CLIENT_SECRET"),
 		WizReportID:     os.Getenv("WIZ_REPORT_ID"),
 
+		// Dependency-Track
+		DTrackBaseURL:     os.Getenv("DTRACK_BASE_URL"),
+		DTrackAPIKey:      os.Getenv("DTRACK_API_KEY"),
+		DTrackProjectUUID: os.Getenv("DTRACK_PROJECT_UUID"),
+		DTrackUpload:      getEnvAsBool("DTRACK_UPLOAD", false),
+
 		// ClickHouse
 		ClickHouseURL:      os.Getenv("CLICKHOUSE_URL"),
 		ClickHouseDatabase: getEnvOrDefault("CLICKHOUSE_DATABASE", "default"),
 		ClickHouseUsername: getEnvOrDefault("CLICKHOUSE_USERNAME", "default"),
 		ClickHousePassword: os.Getenv("CLICKHOUSE_PASSWORD"),
 		TruncateTable:      getEnvAsBool("TRUNCATE_TABLE", false),
+		ForceReingest:      getEnvAsBool("FORCE_REINGEST", false),
+		ScanRetentionDays:  getEnvAsInt("SCAN_RETENTION_DAYS", 0),
+
+		ClickHouseTransport:     getEnvOrDefault("CLICKHOUSE_TRANSPORT", "http"),
+		ClickHouseNativeAddr:    os.Getenv("CLICKHOUSE_NATIVE_ADDR"),
+		ClickHouseTLS:           getEnvAsBool("CLICKHOUSE_TLS", false),
+		ClickHouseCompression:   getEnvOrDefault("CLICKHOUSE_COMPRESSION", "lz4"),
+		ClickHouseBatchSize:     getEnvAsInt("CLICKHOUSE_BATCH_SIZE", 1000),
+		ClickHouseInsertRetries: getEnvAsInt("CLICKHOUSE_INSERT_RETRIES", 3),
+
+		ComponentsBackend: getEnvOrDefault("COMPONENTS_BACKEND", "clickhouse"),
+		PostgresDSN:       os.Getenv("POSTGRES_DSN"),
+		BigQueryProjectID: os.Getenv("BIGQUERY_PROJECT_ID"),
+		BigQueryDataset:   os.Getenv("BIGQUERY_DATASET"),
+		ParquetPrefix:     getEnvOrDefault("PARQUET_PREFIX", "components"),
 
 		// General
-		SBOMSource: getEnvOrDefault("SBOM_SOURCE", "github"),
-		SBOMFormat: getEnvOrDefault("SBOM_FORMAT", "cyclonedx"),
-		Merge:      getEnvAsBool("MERGE", false),
-		Include:    os.Getenv("INCLUDE"),
-		Exclude:    os.Getenv("EXCLUDE"),
-		Debug:      getEnvAsBool("DEBUG", false),
+		SBOMSource:    getEnvOrDefault("SBOM_SOURCE", "github"),
+		SBOMFormat:    getEnvOrDefault("SBOM_FORMAT", "cyclonedx"),
+		Merge:         getEnvAsBool("MERGE", false),
+		MergeStrategy: getEnvOrDefault("MERGE_STRATEGY", "union"),
+		Include:       os.Getenv("INCLUDE"),
+		Exclude:       os.Getenv("EXCLUDE"),
+		Debug:         getEnvAsBool("DEBUG", false),
+		Strict:        getEnvAsBool("STRICT", false),
+
+		// In-toto attestation
+		InTotoWrap:       getEnvAsBool("INTOTO_WRAP", false),
+		InTotoSubjectURI: os.Getenv("INTOTO_SUBJECT_URI"),
+		CosignKeyPath:    os.Getenv("COSIGN_KEY_PATH"),
+
+		// SBOM cryptographic verification
+		VerifySBOM:           os.Getenv("VERIFY_SBOM"),
+		SBOMSignaturePath:    os.Getenv("SBOM_SIGNATURE_PATH"),
+		SBOMCertificatePath:  os.Getenv("SBOM_CERTIFICATE_PATH"),
+		SBOMAttestationPath:  os.Getenv("SBOM_ATTESTATION_PATH"),
+		CosignPublicKeyPath:  os.Getenv("COSIGN_PUBLIC_KEY_PATH"),
+		CosignCertIdentities: os.Getenv("COSIGN_CERT_IDENTITIES"),
+		CosignCertOIDCIssuer: os.Getenv("COSIGN_CERT_OIDC_ISSUER"),
+		RekorURL:             os.Getenv("REKOR_URL"),
+
+		// Vulnerability enrichment
+		VulnEnrichment:        getEnvAsBool("VULN_ENRICHMENT", false),
+		OSVEndpoint:           getEnvOrDefault("OSV_ENDPOINT", "https://api.osv.dev"),
+		VulnEnrichConcurrency: getEnvAsInt("VULN_ENRICH_CONCURRENCY", 4),
+		VulnCacheDir:          os.Getenv("VULN_CACHE_DIR"),
+		VulnCacheTTLHours:     getEnvAsInt("VULN_CACHE_TTL_HOURS", 24),
+		OSVOfflineZipPath:     os.Getenv("OSV_OFFLINE_ZIP_PATH"),
+
+		// SBOM quality scoring
+		MinSBOMScore:   getEnvAsFloat("MIN_SBOM_SCORE", 0),
+		ScoreReportKey: os.Getenv("SCORE_REPORT_KEY"),
+
+		// SBOM archival
+		ArchiveEnabled:        getEnvAsBool("ARCHIVE_ENABLED", false),
+		ArchivePrefix:         getEnvOrDefault("ARCHIVE_PREFIX", "archive"),
+		ArchiveRetentionDays:  getEnvAsInt("ARCHIVE_RETENTION_DAYS", 90),
+		ArchiveRetentionCount: getEnvAsInt("ARCHIVE_RETENTION_COUNT", 0),
+		ArchiveCompression:    getEnvOrDefault("ARCHIVE_COMPRESSION", "gzip"),
+		MergeIncludeArchives:  getEnvAsBool("MERGE_INCLUDE_ARCHIVES", false),
+
+		// SBOM snapshot history
+		SnapshotEnabled: getEnvAsBool("SNAPSHOT_ENABLED", false),
+		SnapshotPrefix:  getEnvOrDefault("SNAPSHOT_PREFIX", "snapshots"),
+		SBOMRetention:   getEnvAsInt("SBOM_RETENTION", 10),
+		SBOMCompression: os.Getenv("SBOM_COMPRESSION"),
+
+		// Outbound HTTP transport
+		HTTPProxyURL:   firstNonEmpty(os.Getenv("HTTP_PROXY_URL"), os.Getenv("HTTPS_PROXY")),
+		HTTPMaxRetries: getEnvAsInt("HTTP_MAX_RETRIES", 3),
+		HTTPNoProxy:    os.Getenv("NO_PROXY"),
+		CustomCABundle: os.Getenv("CUSTOM_CA_BUNDLE"),
+
+		// Output sinks
+		Sinks:        os.Getenv("SINKS"),
+		SinkFilePath: os.Getenv("SINK_FILE_PATH"),
+
+		// OCI registry sink
+		OCIRegistry:   os.Getenv("OCI_REGISTRY"),
+		OCIRepository: os.Getenv("OCI_REPOSITORY"),
+		OCITag:        getEnvOrDefault("OCI_TAG", "latest"),
+		OCIUsername:   os.Getenv("OCI_USERNAME"),
+		OCIPassword:   os.Getenv("OCI_PASSWORD"),
+
+		// Secret resolution
+		VaultAddress: os.Getenv("VAULT_ADDR"),
+		VaultToken:   os.Getenv("VAULT_TOKEN"),
+	}
+
+	// Resolve secret:// and file:// credential references before sanitizing
+	if err := cfg.ResolveSecretRefs(ctx); err != nil {
+		return nil, fmt.Errorf("failed to resolve credentials: %w", err)
 	}
 
 	// Sanitize inputs
@@ -120,24 +399,53 @@ func LoadConfig() (*Config, error) {
 
 // Validate checks that all required configuration fields are set appropriately.
 func (c *Config) Validate() error {
-	// AWS is always required
-	if c.AWSAccessKeyID == "" {
-		return fmt.Errorf("AWS_ACCESS_KEY_ID is required")
-	}
-	if c.AWSSecretAccessKey == "" {
-		return fmt.Errorf("AWS_SECRET_ACCESS_KEY is required")
-	}
 	if c.S3Bucket == "" {
 		return fmt.Errorf("S3_BUCKET is required")
 	}
 
+	switch c.StorageBackend {
+	case "", "s3":
+		if err := c.validateAWSAuth(); err != nil {
+			return err
+		}
+	case "minio":
+		if c.S3EndpointURL == "" {
+			return fmt.Errorf("S3_ENDPOINT_URL is required for minio storage backend")
+		}
+		if err := c.validateAWSAuth(); err != nil {
+			return err
+		}
+	case "gcs":
+		// GCS falls back to Application Default Credentials when
+		// GCS_CREDENTIALS_FILE is not set, so no required fields here.
+	case "azblob":
+		if c.AzureStorageAccount == "" {
+			return fmt.Errorf("AZURE_STORAGE_ACCOUNT is required for azblob storage backend")
+		}
+		if c.AzureSASToken == "" && c.AzureSharedKey == "" {
+			return fmt.Errorf("one of AZURE_SAS_TOKEN or AZURE_SHARED_KEY is required for azblob storage backend")
+		}
+	case "file":
+		if c.FileBaseDir == "" {
+			return fmt.Errorf("FILE_BASE_DIR is required for file storage backend")
+		}
+	default:
+		return fmt.Errorf("unsupported STORAGE_BACKEND: %s", c.StorageBackend)
+	}
+
 	// Repository required if not in merge mode and source is GitHub
-	if !c.Merge && c.SBOMSource != "mend" && c.SBOMSource != "wiz" {
+	if !c.Merge && c.SBOMSource != "mend" && c.SBOMSource != "wiz" && c.SBOMSource != "dtrack" {
 		if c.Repository == "" {
 			return fmt.Errorf("REPOSITORY is required when not in merge mode")
 		}
 	}
 
+	switch c.MergeStrategy {
+	case "", "union", "intersection", "override":
+	default:
+		return fmt.Errorf("unsupported MERGE_STRATEGY: %s", c.MergeStrategy)
+	}
+
 	// Mend validation
 	if c.SBOMSource == "mend" {
 		if c.MendEmail == "" {
@@ -170,6 +478,62 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Dependency-Track validation
+	if c.SBOMSource == "dtrack" {
+		if c.DTrackBaseURL == "" {
+			return fmt.Errorf("DTRACK_BASE_URL is required for Dependency-Track source")
+		}
+		if c.DTrackAPIKey == "" {
+			return fmt.Errorf("DTRACK_API_KEY is required for Dependency-Track source")
+		}
+		if c.DTrackProjectUUID == "" {
+			return fmt.Errorf("DTRACK_PROJECT_UUID is required for Dependency-Track source")
+		}
+	}
+
+	// S3 server-side encryption validation
+	switch c.S3SSEMode {
+	case "", "none", "AES256", "aws:kms":
+	default:
+		return fmt.Errorf("unsupported S3_SSE_MODE: %s", c.S3SSEMode)
+	}
+
+	// SBOM snapshot validation
+	switch c.SBOMCompression {
+	case "", "gzip", "zip":
+	default:
+		return fmt.Errorf("unsupported SBOM_COMPRESSION: %s", c.SBOMCompression)
+	}
+
+	// SBOM archive validation
+	switch c.ArchiveCompression {
+	case "", "gzip", "zip", "zstd":
+	default:
+		return fmt.Errorf("unsupported ARCHIVE_COMPRESSION: %s", c.ArchiveCompression)
+	}
+	if c.SnapshotEnabled && c.SBOMRetention <= 0 {
+		return fmt.Errorf("SBOM_RETENTION must be greater than 0 when SNAPSHOT_ENABLED is true")
+	}
+
+	// Components storage backend validation
+	switch c.ComponentsBackend {
+	case "", "clickhouse":
+	case "postgres":
+		if c.PostgresDSN == "" {
+			return fmt.Errorf("POSTGRES_DSN is required for the postgres components backend")
+		}
+	case "bigquery":
+		if c.BigQueryProjectID == "" || c.BigQueryDataset == "" {
+			return fmt.Errorf("BIGQUERY_PROJECT_ID and BIGQUERY_DATASET are required for the bigquery components backend")
+		}
+	case "s3parquet":
+		if c.S3Bucket == "" {
+			return fmt.Errorf("S3_BUCKET is required for the s3parquet components backend")
+		}
+	default:
+		return fmt.Errorf("unsupported COMPONENTS_BACKEND: %s", c.ComponentsBackend)
+	}
+
 	// ClickHouse validation
 	if c.ClickHouseURL != "" {
 		if c.ClickHouseDatabase == "" {
@@ -178,6 +542,66 @@ func (c *Config) Validate() error {
 		if c.ClickHouseUsername == "" {
 			return fmt.Errorf("CLICKHOUSE_USERNAME is required when using ClickHouse")
 		}
+
+		switch c.ClickHouseTransport {
+		case "", "http":
+		case "native":
+			if c.ClickHouseNativeAddr == "" {
+				return fmt.Errorf("CLICKHOUSE_NATIVE_ADDR is required when CLICKHOUSE_TRANSPORT=native")
+			}
+		default:
+			return fmt.Errorf("unsupported CLICKHOUSE_TRANSPORT: %s", c.ClickHouseTransport)
+		}
+
+		switch c.ClickHouseCompression {
+		case "", "lz4", "none":
+		default:
+			return fmt.Errorf("unsupported CLICKHOUSE_COMPRESSION: %s", c.ClickHouseCompression)
+		}
+	}
+
+	// SBOM verification
+	switch c.VerifySBOM {
+	case "", "optional", "strict":
+	default:
+		return fmt.Errorf("unsupported VERIFY_SBOM: %s", c.VerifySBOM)
+	}
+	if c.VerifySBOM != "" && c.SBOMSignaturePath == "" && c.SBOMAttestationPath == "" {
+		return fmt.Errorf("SBOM_SIGNATURE_PATH or SBOM_ATTESTATION_PATH is required when VERIFY_SBOM is set")
+	}
+	// Keyless verification (CosignPublicKeyPath unset) needs the signing
+	// certificate on disk to enforce COSIGN_CERT_IDENTITIES/
+	// COSIGN_CERT_OIDC_ISSUER against; without it the allow-list is never
+	// inspected and the SBOM is accepted as verified regardless.
+	if c.CosignPublicKeyPath == "" && (c.CosignCertIdentities != "" || c.CosignCertOIDCIssuer != "") && c.SBOMCertificatePath == "" {
+		return fmt.Errorf("SBOM_CERTIFICATE_PATH is required when COSIGN_CERT_IDENTITIES or COSIGN_CERT_OIDC_ISSUER is set")
+	}
+
+	return nil
+}
+
+// validateAWSAuth checks the fields required by c.AWSAuthMode: static keys
+// for "static", an assumable role ARN for "assume-role"/"web-identity",
+// and nothing extra for "default-chain"/"irsa" (the SDK default chain
+// handles those on its own).
+func (c *Config) validateAWSAuth() error {
+	switch c.AWSAuthMode {
+	case "", "static":
+		if c.AWSAccessKeyID == "" {
+			return fmt.Errorf("AWS_ACCESS_KEY_ID is required")
+		}
+		if c.AWSSecretAccessKey == "" {
+			return fmt.Errorf("AWS_SECRET_ACCESS_KEY is required")
+		}
+	case "default-chain", "irsa":
+		// Credentials come from the EC2/ECS/EKS instance role or an IRSA
+		// web identity token; no additional fields required.
+	case "assume-role", "web-identity":
+		if c.AWSRoleARN == "" {
+			return fmt.Errorf("AWS_ROLE_ARN is required for AWS_AUTH_MODE=%s", c.AWSAuthMode)
+		}
+	default:
+		return fmt.Errorf("unsupported AWS_AUTH_MODE: %s", c.AWSAuthMode)
 	}
 
 	return nil
@@ -190,6 +614,16 @@ func getEnvOrDefault(key, defaultVal string) string {
 	return defaultVal
 }
 
+// firstNonEmpty returns the first non-empty value, or "" if all are empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func getEnvAsInt(key string, defaultVal int) int {
 	valStr := os.Getenv(key)
 	if valStr == "" {
@@ -203,6 +637,19 @@ func getEnvAsInt(key string, defaultVal int) int {
 	return val
 }
 
+func getEnvAsFloat(key string, defaultVal float64) float64 {
+	valStr := os.Getenv(key)
+	if valStr == "" {
+		return defaultVal
+	}
+	var val float64
+	_, err := fmt.Sscanf(valStr, "%f", &val)
+	if err != nil {
+		return defaultVal
+	}
+	return val
+}
+
 func getEnvAsBool(key string, defaultVal bool) bool {
 	valStr := os.Getenv(key)
 	if valStr == "" {
@@ -275,6 +722,20 @@ func (c *Config) Sanitize() error {
 		}
 	}
 
+	if c.DTrackBaseURL != "" {
+		c.DTrackBaseURL, err = validation.SanitizeURL(c.DTrackBaseURL, "dtrack")
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.DTrackProjectUUID != "" {
+		c.DTrackProjectUUID, err = validation.SanitizeUUID(c.DTrackProjectUUID, "DTRACK_PROJECT_UUID")
+		if err != nil {
+			return err
+		}
+	}
+
 	// UUIDs
 	if c.MendOrgUUID != "" {
 		c.MendOrgUUID, err = validation.SanitizeUUID(c.MendOrgUUID, "MEND_ORG_UUID")
@@ -301,6 +762,10 @@ func (c *Config) Sanitize() error {
 	c.Include = validation.SanitizePatterns(c.Include)
 	c.Exclude = validation.SanitizePatterns(c.Exclude)
 
+	// S3 SSE / object tags
+	c.S3SSEKMSKeyID = validation.SanitizeString(c.S3SSEKMSKeyID, 500)
+	c.S3ObjectTags = validation.SanitizeTagsString(c.S3ObjectTags)
+
 	// Sanitize strings with length limits
 	c.GitHubToken = validation.SanitizeString(c.GitHubToken, 1000)
 	c.MendUserKey = validation.SanitizeString(c.MendUserKey, 500)
@@ -310,6 +775,9 @@ func (c *Config) Sanitize() error {
 	c.AWSAccessKeyID = validation.SanitizeString(c.AWSAccessKeyID, 100)
 	c.AWSSecretAccessKey = validation.SanitizeString(c.AWSSecretAccessKey, 500)
 	c.ClickHousePassword = validation.SanitizeString(c.ClickHousePassword, 500)
+	c.ClickHouseNativeAddr = validation.SanitizeString(c.ClickHouseNativeAddr, 255)
+	c.DTrackAPIKey = validation.SanitizeString(c.DTrackAPIKey, 500)
+	c.VaultToken = validation.SanitizeString(c.VaultToken, 500)
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+    "context"
     "os"
     "testing"
 )
@@ -53,7 +54,7 @@ func TestLoadConfig(t *testing.T) {
                 os.Setenv(k, v)
             }
             
-            cfg, err := LoadConfig()
+            cfg, err := LoadConfig(context.Background())
             
             if (err != nil) != tt.wantErr {
                 t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
@@ -112,6 +113,61 @@ func TestConfigValidate(t *testing.T) {
             },
             wantErr: true,
         },
+        {
+            name: "invalid verify sbom mode",
+            config: &Config{
+                AWSAccessKeyID:     "key",
+                AWSSecretAccessKey: "secret",
+                S3Bucket:           "bucket",
+                Repository:         "owner/repo",
+                SBOMSource:         "github",
+                VerifySBOM:         "enforced",
+            },
+            wantErr: true,
+        },
+        {
+            name: "verify sbom strict without a signature or attestation",
+            config: &Config{
+                AWSAccessKeyID:     "key",
+                AWSSecretAccessKey: "secret",
+                S3Bucket:           "bucket",
+                Repository:         "owner/repo",
+                SBOMSource:         "github",
+                VerifySBOM:         "strict",
+            },
+            wantErr: true,
+        },
+        {
+            name: "keyless cert identities without a certificate path",
+            config: &Config{
+                AWSAccessKeyID:       "key",
+                AWSSecretAccessKey:   "secret",
+                S3Bucket:             "bucket",
+                Repository:           "owner/repo",
+                SBOMSource:           "github",
+                VerifySBOM:           "strict",
+                SBOMSignaturePath:    "/tmp/sbom.sig",
+                CosignCertIdentities: "repo:owner/repo:ref:refs/heads/main",
+                CosignCertOIDCIssuer: "https://token.actions.githubusercontent.com",
+            },
+            wantErr: true,
+        },
+        {
+            name: "keyless cert identities with a certificate path",
+            config: &Config{
+                AWSAccessKeyID:       "key",
+                AWSSecretAccessKey:   "secret",
+                S3Bucket:             "bucket",
+                Repository:           "owner/repo",
+                SBOMSource:           "github",
+                VerifySBOM:           "strict",
+                SBOMSignaturePath:    "/tmp/sbom.sig",
+                SBOMCertificatePath:  "/tmp/sbom.crt",
+                CosignCertIdentities: "repo:owner/repo:ref:refs/heads/main",
+                CosignCertOIDCIssuer: "https://token.actions.githubusercontent.com",
+            },
+            wantErr: false,
+        },
     }
 
     for _, tt := range tests {
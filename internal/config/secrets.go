@@ -0,0 +1,117 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/internal/secrets"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+const (
+	secretRefPrefix = "secret://"
+	fileRefPrefix   = "file://"
+)
+
+// credentialFields lists the *Config fields that may hold a secret:// or
+// file:// reference instead of a literal value.
+func (c *Config) credentialFields() map[string]*string {
+	return map[string]*string{
+		"AWS_ACCESS_KEY_ID":     &c.AWSAccessKeyID,
+		"AWS_SECRET_ACCESS_KEY": &c.AWSSecretAccessKey,
+		"GITHUB_TOKEN":          &c.GitHubToken,
+		"MEND_USER_KEY":         &c.MendUserKey,
+		"WIZ_CLIENT_SECRET":     &c.WizClientSecret,
+		"DTRACK_API_KEY":        &c.DTrackAPIKey,
+		"CLICKHOUSE_PASSWORD":   &c.ClickHousePassword,
+		"AZURE_SHARED_KEY":      &c.AzureSharedKey,
+		"AZURE_SAS_TOKEN":       &c.AzureSASToken,
+	}
+}
+
+// ResolveSecretRefs resolves any secret:// or file:// references among the
+// credential fields of c to their underlying values. It is called once from
+// LoadConfig, and can be called again from long-running merge operations to
+// pick up rotated credentials.
+func (c *Config) ResolveSecretRefs(ctx context.Context) error {
+	registry := secrets.NewRegistry(
+		secrets.NewK8sResolver(),
+		secrets.NewVaultResolver(c.VaultAddress, c.VaultToken),
+		secrets.NewAWSSecretsManagerResolver(c.AWSRegion),
+	)
+
+	for name, field := range c.credentialFields() {
+		if *field == "" {
+			continue
+		}
+
+		resolved, err := c.resolveRef(ctx, registry, *field)
+		if err != nil {
+			return fmt.Errorf("failed to resolve credential for %s: %w", name, redactRef(*field, err))
+		}
+		*field = resolved
+	}
+
+	return nil
+}
+
+// resolveRef resolves a single secret:// or file:// reference, falling
+// back to the literal value for anything else.
+func (c *Config) resolveRef(ctx context.Context, registry *secrets.Registry, ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, secretRefPrefix):
+		return registry.Resolve(ctx, ref)
+	case strings.HasPrefix(ref, fileRefPrefix):
+		return resolveFile(strings.TrimPrefix(ref, fileRefPrefix))
+	default:
+		return ref, nil
+	}
+}
+
+// redactRef strips any reference payload out of an error so that a
+// secret:// or file:// path (which may embed a namespace/name an attacker
+// could use to enumerate cluster resources) never appears in wrapped
+// errors. Resolver errors never embed the literal ref itself (e.g.
+// secrets.K8sResolver.Resolve wraps just the locator/field it was given,
+// as in "failed to fetch secret %s/%s"), so scrubbing for the full ref
+// string is a no-op; this scrubs for the parsed-out pieces that actually
+// show up instead.
+func redactRef(ref string, err error) error {
+	msg := err.Error()
+	for _, needle := range redactionNeedles(ref) {
+		msg = strings.ReplaceAll(msg, needle, "[REDACTED]")
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// redactionNeedles returns the substrings of ref that a resolver's error
+// messages may embed, longest first so a locator is redacted before its
+// shorter backend/field components could partially match inside it.
+func redactionNeedles(ref string) []string {
+	switch {
+	case strings.HasPrefix(ref, secretRefPrefix):
+		backend, locator, field, ok := secrets.ParseRef(ref)
+		if !ok {
+			return []string{ref}
+		}
+		return []string{locator, field, backend}
+	case strings.HasPrefix(ref, fileRefPrefix):
+		return []string{strings.TrimPrefix(ref, fileRefPrefix)}
+	default:
+		return []string{ref}
+	}
+}
+
+// resolveFile reads a Docker-secret-style file mount and returns its
+// trimmed contents.
+func resolveFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	logger.Debug("Resolved credential from file reference")
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveFile(t *testing.T) {
+	tempDir := t.TempDir()
+	secretFile := filepath.Join(tempDir, "token")
+	if err := os.WriteFile(secretFile, []byte("super-secret\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	value, err := resolveFile(secretFile)
+	if err != nil {
+		t.Fatalf("resolveFile() failed: %v", err)
+	}
+	if value != "super-secret" {
+		t.Errorf("expected trimmed secret value, got %q", value)
+	}
+}
+
+func TestRedactRefSecretLocator(t *testing.T) {
+	ref := "secret://k8s/prod/db-creds#password"
+	err := fmt.Errorf("failed to fetch secret %s/%s: not found", "prod", "db-creds")
+
+	redacted := redactRef(ref, err).Error()
+	if strings.Contains(redacted, "prod") || strings.Contains(redacted, "db-creds") {
+		t.Errorf("expected locator to be redacted, got %q", redacted)
+	}
+}
+
+func TestRedactRefFilePath(t *testing.T) {
+	ref := "file:///var/run/secrets/db-password"
+	err := fmt.Errorf("failed to read secret file: open /var/run/secrets/db-password: permission denied")
+
+	redacted := redactRef(ref, err).Error()
+	if strings.Contains(redacted, "/var/run/secrets/db-password") {
+		t.Errorf("expected file path to be redacted, got %q", redacted)
+	}
+}
@@ -0,0 +1,210 @@
+// Package httpclient builds the shared *http.Client used by every outbound
+// SBOM source integration, giving them a common proxy and retry policy
+// instead of each hand-rolling its own http.Client.
+package httpclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// Config configures the shared HTTP client.
+type Config struct {
+	// Timeout is applied to the whole request, including retries.
+	Timeout time.Duration
+	// ProxyURL, if set, is used for all outbound requests regardless of
+	// scheme. When empty, the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	// environment variables are honored via http.ProxyFromEnvironment.
+	ProxyURL string
+	// NoProxy is a comma-separated list of hosts (exact, or "*" to
+	// disable proxying entirely, or ".suffix" to match subdomains) that
+	// bypass ProxyURL. It has no effect when ProxyURL is empty, since
+	// http.ProxyFromEnvironment already honors NO_PROXY on its own.
+	NoProxy string
+	// CABundlePath, if set, is a PEM file appended to the system trust
+	// store so TLS connections still verify when egress is intercepted
+	// by a corporate proxy, without replacing the system pool.
+	CABundlePath string
+	// MaxRetries is the number of additional attempts after the first.
+	MaxRetries int
+	// RetryBaseDelay is multiplied by the attempt number for backoff.
+	RetryBaseDelay time.Duration
+}
+
+// DefaultConfig returns the Config used when none is supplied.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:        10 * time.Minute,
+		MaxRetries:     3,
+		RetryBaseDelay: 2 * time.Second,
+	}
+}
+
+// NewClient builds an *http.Client whose Transport proxies outbound
+// requests per cfg and retries transport errors and 429, 502, 503 and 504
+// responses.
+func NewClient(cfg Config) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		noProxy := cfg.NoProxy
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassesProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return proxyURL, nil
+		}
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	if cfg.CABundlePath != "" {
+		tlsConfig, err := tlsConfigWithCABundle(cfg.CABundlePath)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &retryTransport{
+			base:       transport,
+			maxRetries: cfg.MaxRetries,
+			baseDelay:  cfg.RetryBaseDelay,
+		},
+	}, nil
+}
+
+// bypassesProxy reports whether host matches an entry in the comma-separated
+// noProxy list. An entry of "*" bypasses the proxy for every host; an entry
+// starting with "." matches that suffix's subdomains; anything else must
+// match host exactly.
+func bypassesProxy(host, noProxy string) bool {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) {
+				return true
+			}
+		case entry == host:
+			return true
+		}
+	}
+	return false
+}
+
+// tlsConfigWithCABundle builds a tls.Config whose RootCAs is the system
+// trust store with caBundlePath's PEM certificates appended, so custom CAs
+// used by a corporate TLS-inspecting proxy are trusted alongside public CAs.
+func tlsConfigWithCABundle(caBundlePath string) (*tls.Config, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	pem, err := os.ReadFile(caBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom CA bundle: %w", err)
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid certificates found in custom CA bundle %s", caBundlePath)
+	}
+
+	return &tls.Config{RootCAs: pool}, nil
+}
+
+// retryTransport wraps an http.RoundTripper, retrying requests that fail
+// with a transport-level error or a retryable status (429, 502, 503, 504).
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var lastErr error
+	delay := t.baseDelay
+
+	for attempt := 0; attempt <= t.maxRetries; attempt++ {
+		if attempt > 0 {
+			logger.Warning("Retrying request to %s (attempt %d/%d) after %v: %v",
+				req.URL.Host, attempt+1, t.maxRetries+1, delay, lastErr)
+			time.Sleep(delay)
+
+			if req.Body != nil && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body for retry: %w", err)
+				}
+				req.Body = body
+			}
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if err != nil {
+			lastErr = err
+			delay = t.baseDelay * time.Duration(attempt+1)
+			continue
+		}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt == t.maxRetries {
+			return resp, nil
+		}
+
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = retryAfter
+		} else {
+			delay = t.baseDelay * time.Duration(attempt+1)
+		}
+		lastErr = fmt.Errorf("retryable status code: %d", resp.StatusCode)
+		resp.Body.Close()
+	}
+
+	return nil, fmt.Errorf("request failed after %d attempts: %w", t.maxRetries+1, lastErr)
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses a Retry-After header expressed either as a
+// delta-seconds integer or an HTTP-date, per RFC 7231 section 7.1.3.
+func parseRetryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,121 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClientInvalidProxy(t *testing.T) {
+	_, err := NewClient(Config{ProxyURL: "://not-a-url"})
+	if err == nil {
+		t.Fatal("expected an error for an invalid proxy URL")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d, ok := parseRetryAfter(""); ok || d != 0 {
+		t.Errorf("expected no delay for an empty header, got %v, %v", d, ok)
+	}
+
+	d, ok := parseRetryAfter("5")
+	if !ok || d != 5*time.Second {
+		t.Errorf("expected 5s for a delta-seconds header, got %v, %v", d, ok)
+	}
+
+	if _, ok := parseRetryAfter("not-a-valid-header"); ok {
+		t.Error("expected an unparsable header to be ignored")
+	}
+}
+
+func TestBypassesProxy(t *testing.T) {
+	tests := []struct {
+		host    string
+		noProxy string
+		want    bool
+	}{
+		{"example.com", "", false},
+		{"example.com", "*", true},
+		{"example.com", "example.com", true},
+		{"api.example.com", ".example.com", true},
+		{"other.com", ".example.com", false},
+		{"other.com", "example.com, other.com", true},
+	}
+
+	for _, tt := range tests {
+		if got := bypassesProxy(tt.host, tt.noProxy); got != tt.want {
+			t.Errorf("bypassesProxy(%q, %q) = %v, want %v", tt.host, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestNewClientInvalidCABundle(t *testing.T) {
+	_, err := NewClient(Config{CABundlePath: "/nonexistent/ca-bundle.pem"})
+	if err == nil {
+		t.Fatal("expected an error for a missing CA bundle file")
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusNotFound, false},
+		{http.StatusTooManyRequests, true},
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusGatewayTimeout, true},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryableStatus(tt.status); got != tt.want {
+			t.Errorf("isRetryableStatus(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRetryTransportResendsBodyOnRetry(t *testing.T) {
+	var bodies []string
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{MaxRetries: 1, RetryBaseDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", attempts)
+	}
+	for i, body := range bodies {
+		if body != "payload" {
+			t.Errorf("attempt %d: expected intact body %q, got %q", i+1, "payload", body)
+		}
+	}
+}
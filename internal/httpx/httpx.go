@@ -0,0 +1,98 @@
+// Package httpx provides an http.RoundTripper for SBOM sources that
+// authenticate with a short-lived bearer or JWT token: it caches the
+// token, refreshes it before expiry or transparently on a 401 response,
+// so callers never need to track token lifetimes themselves.
+package httpx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// TokenFetcher authenticates and returns a bearer token along with the
+// time it expires. A zero expiresAt means the token never expires.
+type TokenFetcher func(ctx context.Context) (token string, expiresAt time.Time, err error)
+
+// expiryMargin is subtracted from a token's reported expiry so a refresh
+// happens slightly before the server would reject it.
+const expiryMargin = 30 * time.Second
+
+// TokenTransport wraps base, injecting a cached bearer token into every
+// request and refreshing it on expiry or a 401 response.
+type TokenTransport struct {
+	base  http.RoundTripper
+	fetch TokenFetcher
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewTokenTransport returns a TokenTransport that authenticates via fetch
+// and sends requests through base.
+func NewTokenTransport(base http.RoundTripper, fetch TokenFetcher) *TokenTransport {
+	return &TokenTransport{base: base, fetch: fetch}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.currentToken(req.Context(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain auth token: %w", err)
+	}
+
+	resp, err := t.base.RoundTrip(withBearer(req, token))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+
+	logger.Debug("Got 401 from %s, refreshing auth token", req.URL.Host)
+	if err := resp.Body.Close(); err != nil {
+		logger.Warning("Failed to close response body: %v", err)
+	}
+
+	token, err = t.currentToken(req.Context(), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh auth token: %w", err)
+	}
+
+	return t.base.RoundTrip(withBearer(req, token))
+}
+
+func (t *TokenTransport) currentToken(ctx context.Context, forceRefresh bool) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !forceRefresh && t.token != "" && (t.expires.IsZero() || time.Now().Before(t.expires)) {
+		return t.token, nil
+	}
+
+	token, expiresAt, err := t.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.token = token
+	if !expiresAt.IsZero() {
+		t.expires = expiresAt.Add(-expiryMargin)
+	} else {
+		t.expires = time.Time{}
+	}
+
+	return t.token, nil
+}
+
+func withBearer(req *http.Request, token string) *http.Request {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return clone
+}
@@ -0,0 +1,75 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenTransportCachesToken(t *testing.T) {
+	fetches := 0
+	fetch := func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return "token-1", time.Now().Add(time.Hour), nil
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-1" {
+			t.Errorf("expected Authorization header with cached token, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewTokenTransport(http.DefaultTransport, fetch)
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if fetches != 1 {
+		t.Errorf("expected exactly 1 token fetch, got %d", fetches)
+	}
+}
+
+func TestTokenTransportRefreshesOn401(t *testing.T) {
+	fetches := 0
+	fetch := func(ctx context.Context) (string, time.Time, error) {
+		fetches++
+		return "token-" + time.Now().String(), time.Now().Add(time.Hour), nil
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := NewTokenTransport(http.DefaultTransport, fetch)
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected eventual 200, got %d", resp.StatusCode)
+	}
+	if fetches != 2 {
+		t.Errorf("expected a refetch after the 401, got %d fetches", fetches)
+	}
+}
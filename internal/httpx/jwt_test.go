@@ -0,0 +1,28 @@
+package httpx
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestParseJWTExpiry(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":` + strconv.FormatInt(exp, 10) + `}`))
+	token := "header." + payload + ".signature"
+
+	got, err := ParseJWTExpiry(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Unix() != exp {
+		t.Errorf("expected expiry %d, got %d", exp, got.Unix())
+	}
+}
+
+func TestParseJWTExpiryInvalid(t *testing.T) {
+	if _, err := ParseJWTExpiry("not-a-jwt"); err == nil {
+		t.Fatal("expected an error for a malformed token")
+	}
+}
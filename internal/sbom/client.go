@@ -0,0 +1,20 @@
+package sbom
+
+import (
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
+)
+
+// httpConfigFrom builds the shared HTTP client configuration for outbound
+// SBOM source requests from cfg, falling back to httpclient.DefaultConfig
+// for anything the user didn't set.
+func httpConfigFrom(cfg *config.Config) httpclient.Config {
+	httpCfg := httpclient.DefaultConfig()
+	httpCfg.ProxyURL = cfg.HTTPProxyURL
+	httpCfg.NoProxy = cfg.HTTPNoProxy
+	httpCfg.CABundlePath = cfg.CustomCABundle
+	if cfg.HTTPMaxRetries > 0 {
+		httpCfg.MaxRetries = cfg.HTTPMaxRetries
+	}
+	return httpCfg
+}
@@ -0,0 +1,196 @@
+package sbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// maxDownloadAttempts bounds how many times downloadToFile resumes a
+// stalled transfer before giving up.
+const maxDownloadAttempts = 5
+
+// DownloadOptions configures downloadToFile.
+type DownloadOptions struct {
+	// ExpectedChecksum, if set, is a "sha256:<hex>" or "sha512:<hex>"
+	// digest the completed download must match.
+	ExpectedChecksum string
+}
+
+// downloadToFile streams the response of req to outputFile, resuming via
+// an HTTP Range request from a ".part" file left by a prior attempt, and
+// verifying opts.ExpectedChecksum (if set) once the transfer completes.
+// The target file is only created via an atomic rename once both the
+// transfer and checksum (if any) succeed.
+func downloadToFile(ctx context.Context, client *http.Client, req *http.Request, outputFile string, opts DownloadOptions) error {
+	partFile := outputFile + ".part"
+
+	offset, err := partialSize(partFile)
+	if err != nil {
+		return fmt.Errorf("failed to inspect partial download: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		if attempt > 1 {
+			logger.Warning("Resuming download from byte %d (attempt %d/%d) after: %v", offset, attempt, maxDownloadAttempts, lastErr)
+		}
+
+		newOffset, err := attemptDownload(ctx, client, req, partFile, offset)
+		if err == nil {
+			offset = newOffset
+			lastErr = nil
+			break
+		}
+		offset = newOffset
+		lastErr = err
+	}
+	if lastErr != nil {
+		return fmt.Errorf("download failed after %d attempts: %w", maxDownloadAttempts, lastErr)
+	}
+
+	if opts.ExpectedChecksum != "" {
+		if err := verifyChecksum(partFile, opts.ExpectedChecksum); err != nil {
+			return err
+		}
+	}
+
+	if err := os.Rename(partFile, outputFile); err != nil {
+		return fmt.Errorf("failed to finalize downloaded file: %w", err)
+	}
+
+	return nil
+}
+
+// partialSize returns the size of a previous attempt's ".part" file, or 0
+// if none exists yet.
+func partialSize(partFile string) (int64, error) {
+	info, err := os.Stat(partFile)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// attemptDownload performs a single download attempt starting at offset,
+// appending to partFile, and returns the byte offset reached (so a
+// subsequent attempt can resume from there even on error).
+func attemptDownload(ctx context.Context, client *http.Client, req *http.Request, partFile string, offset int64) (int64, error) {
+	attemptReq := req.Clone(ctx)
+	if offset > 0 {
+		attemptReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := client.Do(attemptReq)
+	if err != nil {
+		return offset, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Server ignored our Range request (or this is the first
+		// attempt); start the file over from scratch.
+		offset = 0
+	case http.StatusPartialContent:
+		if got := contentRangeStart(resp.Header.Get("Content-Range")); got >= 0 && got != offset {
+			offset = got
+		}
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return offset, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if offset > 0 {
+		flags |= os.O_APPEND
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	out, err := os.OpenFile(partFile, flags, 0o644)
+	if err != nil {
+		return offset, fmt.Errorf("failed to open partial file: %w", err)
+	}
+	defer func() {
+		if err := out.Close(); err != nil {
+			logger.Warning("Failed to close file: %v", err)
+		}
+	}()
+
+	written, err := io.Copy(out, resp.Body)
+	offset += written
+	if err != nil {
+		return offset, fmt.Errorf("failed to write response body: %w", err)
+	}
+
+	return offset, nil
+}
+
+// contentRangeStart parses the start offset out of a Content-Range header
+// of the form "bytes 1000-2000/3000", returning -1 if it can't be parsed.
+func contentRangeStart(header string) int64 {
+	header = strings.TrimPrefix(header, "bytes ")
+	dash := strings.IndexByte(header, '-')
+	if dash <= 0 {
+		return -1
+	}
+	start, err := strconv.ParseInt(header[:dash], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return start
+}
+
+// verifyChecksum checks file against an "algo:hex" digest such as
+// "sha256:deadbeef...".
+func verifyChecksum(file, expected string) error {
+	algo, wantHex, ok := strings.Cut(expected, ":")
+	if !ok {
+		return fmt.Errorf("invalid checksum %q: expected \"algo:hex\"", expected)
+	}
+
+	var h hash.Hash
+	switch strings.ToLower(algo) {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported checksum algorithm: %s", algo)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("failed to open file for checksum verification: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash file: %w", err)
+	}
+
+	gotHex := hex.EncodeToString(h.Sum(nil))
+	if !strings.EqualFold(gotHex, wantHex) {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s:%s", expected, algo, gotHex)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package sbom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDownloadToFileResumesPartialDownload(t *testing.T) {
+	content := []byte("the quick brown fox jumps over the lazy dog")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rangeHeader := r.Header.Get("Range")
+		if rangeHeader == "" {
+			w.WriteHeader(http.StatusOK)
+			w.Write(content)
+			return
+		}
+
+		start, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+		if err != nil {
+			t.Fatalf("failed to parse Range header %q: %v", rangeHeader, err)
+		}
+		w.Header().Set("Content-Range", "bytes "+strconv.Itoa(start)+"-"+strconv.Itoa(len(content)-1)+"/"+strconv.Itoa(len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content[start:])
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "out.txt")
+	partFile := outputFile + ".part"
+
+	if err := os.WriteFile(partFile, content[:10], 0o644); err != nil {
+		t.Fatalf("failed to seed partial file: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := downloadToFile(context.Background(), server.Client(), req, outputFile, DownloadOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+}
+
+func TestDownloadToFileVerifiesChecksum(t *testing.T) {
+	content := []byte("sbom contents")
+	sum := sha256.Sum256(content)
+	checksum := "sha256:" + hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "out.txt")
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := downloadToFile(context.Background(), server.Client(), req, outputFile, DownloadOptions{ExpectedChecksum: checksum}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(outputFile); err != nil {
+		t.Errorf("expected output file to exist: %v", err)
+	}
+}
+
+func TestDownloadToFileChecksumMismatch(t *testing.T) {
+	content := []byte("sbom contents")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "out.txt")
+
+	req, err := http.NewRequestWithContext(context.Background(), "GET", server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = downloadToFile(context.Background(), server.Client(), req, outputFile, DownloadOptions{ExpectedChecksum: "sha256:0000000000000000000000000000000000000000000000000000000000000000"})
+	if err == nil {
+		t.Fatal("expected a checksum mismatch error")
+	}
+	if _, statErr := os.Stat(outputFile); statErr == nil {
+		t.Error("expected the output file not to be created on checksum mismatch")
+	}
+}
+
+func TestContentRangeStart(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int64
+	}{
+		{"bytes 100-199/200", 100},
+		{"", -1},
+		{"not-a-range", -1},
+	}
+
+	for _, tt := range tests {
+		if got := contentRangeStart(tt.header); got != tt.want {
+			t.Errorf("contentRangeStart(%q) = %d, want %d", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Package sbom provides functionalities to interact with Dependency-Track for SBOM upload/download.
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// DTrackClient handles interactions with a Dependency-Track server.
+type DTrackClient struct {
+	baseURL     string
+	apiKey      string
+	projectUUID string
+	httpClient  *http.Client
+}
+
+// NewDTrackClient creates a new DTrackClient with the provided configuration.
+func NewDTrackClient(cfg *config.Config) (*DTrackClient, error) {
+	client, err := httpclient.NewClient(httpConfigFrom(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	return &DTrackClient{
+		baseURL:     cfg.DTrackBaseURL,
+		apiKey:      cfg.DTrackAPIKey,
+		projectUUID: cfg.DTrackProjectUUID,
+		httpClient:  client,
+	}, nil
+}
+
+// Name implements Source.
+func (d *DTrackClient) Name() string { return "dtrack" }
+
+// Validate implements Source.
+func (d *DTrackClient) Validate() error {
+	if d.baseURL == "" || d.apiKey == "" || d.projectUUID == "" {
+		return fmt.Errorf("dtrack: base URL, API key, and project UUID are required")
+	}
+	return nil
+}
+
+// Fetch implements Source by downloading the project's current BOM.
+func (d *DTrackClient) Fetch(ctx context.Context, outputFile string) error {
+	return d.DownloadSBOM(ctx, outputFile)
+}
+
+func init() {
+	Register("dtrack", func(cfg *config.Config) (Source, error) {
+		return NewDTrackClient(cfg)
+	})
+}
+
+func (d *DTrackClient) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, d.baseURL+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("X-Api-Key", d.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// DownloadSBOM pulls the current CycloneDX BOM for the configured project UUID.
+func (d *DTrackClient) DownloadSBOM(ctx context.Context, outputFile string) error {
+	logger.Info("Downloading SBOM from Dependency-Track (project: %s)", d.projectUUID)
+
+	path := fmt.Sprintf("/api/v1/bom/cyclonedx/project/%s", d.projectUUID)
+	req, err := d.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download SBOM: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("Dependency-Track API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	outFile, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer func() {
+		if err := outFile.Close(); err != nil {
+			logger.Warning("Failed to close file: %v", err)
+		}
+	}()
+
+	written, err := io.Copy(outFile, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to write SBOM to file: %w", err)
+	}
+
+	logger.Success("Dependency-Track SBOM downloaded successfully (%d bytes)", written)
+	return nil
+}
+
+// UploadSBOM uploads the SBOM at inputFile for the configured project UUID
+// and waits for Dependency-Track to finish processing it.
+func (d *DTrackClient) UploadSBOM(ctx context.Context, inputFile string) error {
+	logger.Info("Uploading SBOM to Dependency-Track (project: %s)", d.projectUUID)
+
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	payload := map[string]string{
+		"project": d.projectUUID,
+		"bom":     base64.StdEncoding.EncodeToString(data),
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "POST", "/api/v1/bom", bytes.NewReader(payloadBytes))
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("upload failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var uploadResp struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&uploadResp); err != nil {
+		return fmt.Errorf("failed to parse upload response: %w", err)
+	}
+
+	if uploadResp.Token == "" {
+		logger.Warning("No processing token returned, assuming upload is synchronous")
+		return nil
+	}
+
+	if err := d.waitForProcessing(ctx, uploadResp.Token); err != nil {
+		return fmt.Errorf("failed waiting for BOM processing: %w", err)
+	}
+
+	logger.Success("SBOM uploaded and processed successfully by Dependency-Track")
+	return nil
+}
+
+// waitForProcessing polls the async BOM processing token until it completes.
+func (d *DTrackClient) waitForProcessing(ctx context.Context, token string) error {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	timeout := time.After(10 * time.Minute)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-timeout:
+			return fmt.Errorf("timeout waiting for BOM processing token %s", token)
+
+		case <-ticker.C:
+			processing, err := d.isProcessing(ctx, token)
+			if err != nil {
+				logger.Warning("Failed to check processing status: %v", err)
+				continue
+			}
+
+			if !processing {
+				return nil
+			}
+			logger.Debug("BOM still processing (token: %s)", token)
+		}
+	}
+}
+
+func (d *DTrackClient) isProcessing(ctx context.Context, token string) (bool, error) {
+	req, err := d.newRequest(ctx, "GET", "/api/v1/bom/token/"+token, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	var statusResp struct {
+		Processing bool `json:"processing"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
+		return false, err
+	}
+
+	return statusResp.Processing, nil
+}
+
+// TagProject tags the Dependency-Track project with metadata about the
+// source of the SBOM, following the tagging pattern used by sbomqs'
+// DtrackScore to make provenance discoverable in the Dependency-Track UI.
+func (d *DTrackClient) TagProject(ctx context.Context, sourceRepo, commit, sbomFormat string) error {
+	tags := []string{
+		fmt.Sprintf("source:%s", sourceRepo),
+		fmt.Sprintf("commit:%s", commit),
+		fmt.Sprintf("format:%s", sbomFormat),
+	}
+
+	payload := map[string]interface{}{
+		"tags": tags,
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tags: %w", err)
+	}
+
+	req, err := d.newRequest(ctx, "PATCH", "/api/v1/project/"+d.projectUUID, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return err
+	}
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tag request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("tagging failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	logger.Success("Tagged Dependency-Track project with source metadata")
+	return nil
+}
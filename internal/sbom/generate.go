@@ -0,0 +1,166 @@
+// Package sbom provides functionalities to interact with Software Bill of Materials (SBOM).
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// TargetKind identifies what a GenerateTarget points at.
+type TargetKind string
+
+const (
+	// TargetDirectory points Generate at a local source directory.
+	TargetDirectory TargetKind = "dir"
+	// TargetImage points Generate at a container image reference, pulled
+	// from a registry.
+	TargetImage TargetKind = "image"
+	// TargetOCITarball points Generate at a local OCI image tarball.
+	TargetOCITarball TargetKind = "oci-tarball"
+)
+
+// GenerateTarget identifies what Generate should scan: a source directory,
+// a container image reference, or an OCI tarball on disk. Exactly one of
+// Path or Ref is set, matching Kind.
+type GenerateTarget struct {
+	Kind TargetKind
+	Path string
+	Ref  string
+}
+
+// source returns the syft CLI source argument for the target, e.g.
+// "dir:./src", "registry:alpine:3.19", or "oci-archive:./image.tar".
+func (t GenerateTarget) source() (string, error) {
+	switch t.Kind {
+	case TargetDirectory:
+		if t.Path == "" {
+			return "", fmt.Errorf("generate target %q requires Path", t.Kind)
+		}
+		return "dir:" + t.Path, nil
+	case TargetImage:
+		if t.Ref == "" {
+			return "", fmt.Errorf("generate target %q requires Ref", t.Kind)
+		}
+		return "registry:" + t.Ref, nil
+	case TargetOCITarball:
+		if t.Path == "" {
+			return "", fmt.Errorf("generate target %q requires Path", t.Kind)
+		}
+		return "oci-archive:" + t.Path, nil
+	default:
+		return "", fmt.Errorf("unknown generate target kind: %q", t.Kind)
+	}
+}
+
+// GenerateOptions configures a Generate call.
+type GenerateOptions struct {
+	// MediaType selects the output spec and version, e.g.
+	// MediaTypeCycloneDX15 or MediaTypeSPDX23. Defaults to
+	// MediaTypeCycloneDX15.
+	MediaType string
+	// Catalogers, if non-empty, restricts which syft catalogers run (e.g.
+	// "go-module-binary-cataloger").
+	Catalogers []string
+}
+
+// GeneratorBackend generates an SBOM for target, writing it to out in the
+// mediatype requested by opts. SyftGenerator is the only backend ClickBOM
+// ships; a trivy or grype based generator can be plugged in with
+// SetGeneratorBackend by implementing this interface.
+type GeneratorBackend interface {
+	Generate(ctx context.Context, target GenerateTarget, out string, opts GenerateOptions) error
+}
+
+// Executable runs an external command and returns its combined stdout and
+// stderr. It exists so tests can inject a fake rather than shelling out to
+// the real syft binary.
+type Executable interface {
+	Run(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// execExecutable is the production Executable, running commands via
+// os/exec.
+type execExecutable struct{}
+
+func (execExecutable) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
+
+// syftOutputSelectors maps a mediatype to syft's --output format selector.
+var syftOutputSelectors = map[string]string{
+	MediaTypeCycloneDX14: "cyclonedx-json@1.4",
+	MediaTypeCycloneDX15: "cyclonedx-json@1.5",
+	MediaTypeCycloneDX16: "cyclonedx-json@1.6",
+	MediaTypeSPDX22:      "spdx-json@2.2",
+	MediaTypeSPDX23:      "spdx-json@2.3",
+}
+
+// SyftGenerator is the default GeneratorBackend, driving the syft CLI.
+type SyftGenerator struct {
+	// Exec runs the syft binary. Defaults to the real os/exec-backed
+	// Executable when nil.
+	Exec Executable
+}
+
+// Generate implements GeneratorBackend by invoking `syft scan`.
+func (g SyftGenerator) Generate(ctx context.Context, target GenerateTarget, out string, opts GenerateOptions) error {
+	runner := g.Exec
+	if runner == nil {
+		if _, err := exec.LookPath("syft"); err != nil {
+			return fmt.Errorf("syft binary not found on PATH; install it from https://github.com/anchore/syft: %w", err)
+		}
+		runner = execExecutable{}
+	}
+
+	source, err := target.source()
+	if err != nil {
+		return err
+	}
+
+	mediaType := opts.MediaType
+	if mediaType == "" {
+		mediaType = MediaTypeCycloneDX15
+	}
+	selector, ok := syftOutputSelectors[mediaType]
+	if !ok {
+		return fmt.Errorf("unsupported mediatype for syft generation: %q", mediaType)
+	}
+
+	args := []string{"scan", "-q", "--output", selector + "=" + out}
+	if len(opts.Catalogers) > 0 {
+		args = append(args, "--select-catalogers", strings.Join(opts.Catalogers, ","))
+	}
+	args = append(args, source)
+
+	logger.Info("Generating SBOM with syft (source=%s, output=%s)", source, selector)
+
+	output, err := runner.Run(ctx, "syft", args...)
+	if err != nil {
+		logger.Error("syft failed: %s", string(output))
+		return fmt.Errorf("syft scan failed: %w", err)
+	}
+
+	logger.Success("SBOM generated with syft")
+	return nil
+}
+
+// defaultGenerator is the GeneratorBackend Generate delegates to. Swap it
+// with SetGeneratorBackend to use a generator other than syft.
+var defaultGenerator GeneratorBackend = SyftGenerator{}
+
+// SetGeneratorBackend replaces the GeneratorBackend Generate uses, so a
+// trivy or grype based generator can stand in for syft.
+func SetGeneratorBackend(b GeneratorBackend) {
+	defaultGenerator = b
+}
+
+// Generate generates an SBOM for target and writes it to out per opts,
+// using the current GeneratorBackend (syft by default). This turns
+// ClickBOM into a one-stop tool that can both produce and transform SBOMs.
+func Generate(ctx context.Context, target GenerateTarget, out string, opts GenerateOptions) error {
+	return defaultGenerator.Generate(ctx, target, out, opts)
+}
@@ -0,0 +1,84 @@
+package sbom
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type fakeExecutable struct {
+	name string
+	args []string
+	err  error
+}
+
+func (f *fakeExecutable) Run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	f.name = name
+	f.args = args
+	return []byte("fake output"), f.err
+}
+
+func TestSyftGeneratorBuildsExpectedArgs(t *testing.T) {
+	fake := &fakeExecutable{}
+	gen := SyftGenerator{Exec: fake}
+
+	target := GenerateTarget{Kind: TargetDirectory, Path: "./src"}
+	opts := GenerateOptions{MediaType: MediaTypeSPDX23, Catalogers: []string{"go-module-binary-cataloger"}}
+
+	if err := gen.Generate(context.Background(), target, "out.json", opts); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	joined := strings.Join(fake.args, " ")
+	if !strings.Contains(joined, "spdx-json@2.3=out.json") {
+		t.Errorf("expected output selector pinned to SPDX 2.3, got args: %v", fake.args)
+	}
+	if !strings.Contains(joined, "dir:./src") {
+		t.Errorf("expected dir: source, got args: %v", fake.args)
+	}
+	if !strings.Contains(joined, "go-module-binary-cataloger") {
+		t.Errorf("expected catalogers to be passed through, got args: %v", fake.args)
+	}
+}
+
+func TestSyftGeneratorRejectsUnknownMediaType(t *testing.T) {
+	gen := SyftGenerator{Exec: &fakeExecutable{}}
+	target := GenerateTarget{Kind: TargetDirectory, Path: "./src"}
+
+	err := gen.Generate(context.Background(), target, "out.json", GenerateOptions{MediaType: "application/x-unknown"})
+	if err == nil {
+		t.Fatal("expected an error for an unregistered mediatype")
+	}
+}
+
+func TestGenerateTargetSourceRequiresMatchingField(t *testing.T) {
+	if _, err := (GenerateTarget{Kind: TargetImage}).source(); err == nil {
+		t.Error("expected an error when TargetImage has no Ref")
+	}
+	if _, err := (GenerateTarget{Kind: TargetOCITarball}).source(); err == nil {
+		t.Error("expected an error when TargetOCITarball has no Path")
+	}
+
+	source, err := (GenerateTarget{Kind: TargetImage, Ref: "alpine:3.19"}).source()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source != "registry:alpine:3.19" {
+		t.Errorf("expected registry: source, got %s", source)
+	}
+}
+
+func TestSetGeneratorBackend(t *testing.T) {
+	t.Cleanup(func() { SetGeneratorBackend(SyftGenerator{}) })
+
+	fake := &fakeExecutable{}
+	SetGeneratorBackend(SyftGenerator{Exec: fake})
+
+	target := GenerateTarget{Kind: TargetDirectory, Path: "./src"}
+	if err := Generate(context.Background(), target, "out.json", GenerateOptions{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if fake.name != "syft" {
+		t.Errorf("expected the swapped backend to be used, got command %q", fake.name)
+	}
+}
@@ -10,23 +10,55 @@ import (
 	"os"
 	"time"
 
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
 	"github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
 // GitHubClient handles interactions with the GitHub API.
 type GitHubClient struct {
 	token      string
+	repo       string
 	httpClient *http.Client
 }
 
 // NewGitHubClient creates a new GitHubClient with the provided token.
-func NewGitHubClient(token string) *GitHubClient {
+func NewGitHubClient(token string, cfg *config.Config) (*GitHubClient, error) {
+	client, err := httpclient.NewClient(httpConfigFrom(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
 	return &GitHubClient{
-		token: token,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Minute,
-		},
+		token:      token,
+		repo:       cfg.Repository,
+		httpClient: client,
+	}, nil
+}
+
+// Name implements Source.
+func (g *GitHubClient) Name() string { return "github" }
+
+// Validate implements Source.
+func (g *GitHubClient) Validate() error {
+	if g.token == "" {
+		return fmt.Errorf("github: token is required")
 	}
+	if g.repo == "" {
+		return fmt.Errorf("github: repository is required")
+	}
+	return nil
+}
+
+// Fetch implements Source by downloading the configured repository's SBOM.
+func (g *GitHubClient) Fetch(ctx context.Context, outputFile string) error {
+	return g.DownloadSBOM(ctx, g.repo, outputFile)
+}
+
+func init() {
+	Register("github", func(cfg *config.Config) (Source, error) {
+		return NewGitHubClient(cfg.GitHubToken, cfg)
+	})
 }
 
 // DownloadSBOM downloads the SBOM from the specified GitHub repository.
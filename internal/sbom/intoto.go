@@ -0,0 +1,257 @@
+// Package sbom provides functionalities to interact with Software Bill of Materials (SBOM).
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+const inTotoPayloadType = "application/vnd.in-toto+json"
+
+// DSSEEnvelope represents a DSSE envelope as produced by Trivy, Syft, and
+// Tekton Chains for in-toto attestations.
+type DSSEEnvelope struct {
+	PayloadType string          `json:"payloadType"`
+	Payload     string          `json:"payload"`
+	Signatures  []DSSESignature `json:"signatures,omitempty"`
+}
+
+// DSSESignature represents a single signature on a DSSE envelope.
+type DSSESignature struct {
+	KeyID string `json:"keyid,omitempty"`
+	Sig   string `json:"sig"`
+}
+
+// InTotoStatement represents the in-toto Statement embedded in an envelope's
+// base64-encoded payload.
+type InTotoStatement struct {
+	Type          string          `json:"_type"`
+	PredicateType string          `json:"predicateType"`
+	Subject       []InTotoSubject `json:"subject,omitempty"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// InTotoSubject identifies the artifact an attestation's predicate is about.
+type InTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// isInTotoAttestation reports whether data looks like a DSSE/in-toto
+// envelope, either a single JSON object or a JSON-lines file of envelopes.
+func isInTotoAttestation(data []byte) bool {
+	env, ok := firstEnvelope(data)
+	if !ok {
+		return false
+	}
+	return env.PayloadType == inTotoPayloadType
+}
+
+// attestationPredicateFormat peeks the first DSSE envelope's embedded
+// predicateType, without fully validating the statement, and reports which
+// SBOM format its predicate holds. It returns FormatInTotoAttestation for
+// attestations whose predicateType isn't a recognized SBOM kind.
+func attestationPredicateFormat(data []byte) Format {
+	env, ok := firstEnvelope(data)
+	if !ok {
+		return FormatUnknown
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return FormatInTotoAttestation
+	}
+
+	var statement struct {
+		PredicateType string `json:"predicateType"`
+	}
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return FormatInTotoAttestation
+	}
+
+	switch {
+	case strings.HasPrefix(statement.PredicateType, "https://cyclonedx.org/"):
+		return FormatAttestCycloneDXJSON
+	case strings.HasPrefix(statement.PredicateType, "https://spdx.dev/"):
+		return FormatAttestSPDXJSON
+	default:
+		return FormatInTotoAttestation
+	}
+}
+
+// firstEnvelope parses the first DSSE envelope out of data, handling both a
+// single JSON object and JSONL (one envelope per line).
+func firstEnvelope(data []byte) (DSSEEnvelope, bool) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return DSSEEnvelope{}, false
+	}
+
+	var env DSSEEnvelope
+	if err := json.Unmarshal(trimmed, &env); err == nil && env.PayloadType != "" {
+		return env, true
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(line, &env); err == nil && env.PayloadType != "" {
+			return env, true
+		}
+		break
+	}
+
+	return DSSEEnvelope{}, false
+}
+
+// extractFromInTotoAttestation decodes the first envelope in data, validates
+// the embedded statement, and writes just the inner predicate (the
+// CycloneDX/SPDX document) to outputFile.
+func extractFromInTotoAttestation(data []byte, outputFile string) error {
+	logger.Info("Found in-toto attestation, unwrapping...")
+
+	env, ok := firstEnvelope(data)
+	if !ok {
+		return fmt.Errorf("no in-toto attestation envelope found")
+	}
+
+	statement, err := decodeStatement(env)
+	if err != nil {
+		return fmt.Errorf("failed to decode in-toto statement: %w", err)
+	}
+
+	logger.Info("Attestation predicateType: %s", statement.PredicateType)
+
+	if len(statement.Predicate) == 0 {
+		return fmt.Errorf("in-toto statement has no predicate")
+	}
+
+	predicate, err := json.MarshalIndent(json.RawMessage(statement.Predicate), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal predicate: %w", err)
+	}
+
+	if err := os.WriteFile(outputFile, predicate, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	logger.Success("SBOM extracted from in-toto attestation")
+	return nil
+}
+
+// decodeStatement base64-decodes and validates the payload of a DSSE
+// envelope, returning the embedded in-toto Statement.
+func decodeStatement(env DSSEEnvelope) (*InTotoStatement, error) {
+	if env.PayloadType != inTotoPayloadType {
+		return nil, fmt.Errorf("unsupported payloadType: %s", env.PayloadType)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to base64-decode payload: %w", err)
+	}
+
+	var statement InTotoStatement
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return nil, fmt.Errorf("failed to parse statement: %w", err)
+	}
+
+	if !strings.HasPrefix(statement.Type, "https://in-toto.io/Statement") {
+		return nil, fmt.Errorf("unexpected statement type: %s", statement.Type)
+	}
+
+	return &statement, nil
+}
+
+// WrapInTotoStatement wraps the SBOM at inputFile in an in-toto Statement and
+// DSSE envelope, writing the envelope to outputFile. subjectURI and
+// subjectDigest identify the artifact the attestation is about (e.g. the OCI
+// image or repository the SBOM was generated for). If cosignKeyPath is
+// non-empty, outputFile is then replaced by the attestation that
+// `cosign attest-blob` builds and signs with that key, and ctx governs that
+// subprocess; the subject of that signed attestation is inputFile itself,
+// not subjectURI/subjectDigest.
+func WrapInTotoStatement(ctx context.Context, inputFile, outputFile, predicateType, subjectURI, subjectDigest, cosignKeyPath string) error {
+	logger.Info("Wrapping SBOM in in-toto attestation")
+
+	predicate, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	statement := InTotoStatement{
+		Type:          "https://in-toto.io/Statement/v1",
+		PredicateType: predicateType,
+		Subject: []InTotoSubject{
+			{
+				Name:   subjectURI,
+				Digest: map[string]string{"sha256": subjectDigest},
+			},
+		},
+		Predicate: json.RawMessage(predicate),
+	}
+
+	statementBytes, err := json.Marshal(statement)
+	if err != nil {
+		return fmt.Errorf("failed to marshal statement: %w", err)
+	}
+
+	envelope := DSSEEnvelope{
+		PayloadType: inTotoPayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(statementBytes),
+	}
+
+	envelopeBytes, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal envelope: %w", err)
+	}
+
+	if err := os.WriteFile(outputFile, envelopeBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write attestation: %w", err)
+	}
+
+	if cosignKeyPath == "" {
+		logger.Success("SBOM wrapped in in-toto attestation")
+		return nil
+	}
+
+	return signWithCosign(ctx, inputFile, outputFile, predicateType, cosignKeyPath)
+}
+
+// signWithCosign overwrites outputFile with a new attestation built and
+// signed by `cosign attest-blob`, with inputFile as both the predicate and
+// the subject blob, following the signable-SBOM pattern used upstream by
+// Tekton Chains (predicate + key in, signed DSSE envelope out).
+func signWithCosign(ctx context.Context, inputFile, outputFile, predicateType, keyPath string) error {
+	logger.Info("Signing attestation with cosign (key: %s)", keyPath)
+
+	cmd := exec.CommandContext(ctx, "cosign", "attest-blob",
+		"--predicate", inputFile,
+		"--type", predicateType,
+		"--key", keyPath,
+		"--output-attestation", outputFile,
+		"--yes",
+		inputFile,
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("cosign signing failed: %w\nOutput: %s", err, string(output))
+	}
+
+	logger.Success("Attestation signed with cosign")
+	return nil
+}
@@ -0,0 +1,106 @@
+package sbom
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractFromInTotoAttestation(t *testing.T) {
+	statement := `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://cyclonedx.org/bom","subject":[{"name":"pkg:test","digest":{"sha256":"abc"}}],"predicate":{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}}`
+	payload := base64.StdEncoding.EncodeToString([]byte(statement))
+	envelope := `{"payloadType":"application/vnd.in-toto+json","payload":"` + payload + `"}`
+
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "attestation.json")
+	if err := os.WriteFile(inputFile, []byte(envelope), 0644); err != nil {
+		t.Fatalf("failed to write attestation: %v", err)
+	}
+
+	format, err := DetectSBOMFormat(inputFile)
+	if err != nil {
+		t.Fatalf("DetectSBOMFormat failed: %v", err)
+	}
+	if format != FormatAttestCycloneDXJSON {
+		t.Fatalf("expected FormatAttestCycloneDXJSON, got %s", format)
+	}
+
+	outputFile := filepath.Join(tempDir, "extracted.json")
+	if err := ExtractSBOMFromWrapper(inputFile, outputFile); err != nil {
+		t.Fatalf("ExtractSBOMFromWrapper failed: %v", err)
+	}
+
+	extracted, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("failed to read extracted file: %v", err)
+	}
+
+	innerFormat, err := DetectSBOMFormat(outputFile)
+	if err != nil {
+		t.Fatalf("DetectSBOMFormat on unwrapped SBOM failed: %v", err)
+	}
+	if innerFormat != FormatCycloneDX {
+		t.Errorf("expected unwrapped format to be CycloneDX, got %s", innerFormat)
+	}
+
+	if len(extracted) == 0 {
+		t.Error("expected non-empty extracted SBOM")
+	}
+}
+
+func TestDetectSBOMFormatAttestationPredicateTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		predicateType string
+		want          Format
+	}{
+		{"cyclonedx predicate", "https://cyclonedx.org/bom", FormatAttestCycloneDXJSON},
+		{"spdx predicate", "https://spdx.dev/Document", FormatAttestSPDXJSON},
+		{"unrecognized predicate", "https://example.com/some-other-predicate", FormatInTotoAttestation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement := `{"_type":"https://in-toto.io/Statement/v1","predicateType":"` + tt.predicateType + `","predicate":{}}`
+			payload := base64.StdEncoding.EncodeToString([]byte(statement))
+			envelope := `{"payloadType":"application/vnd.in-toto+json","payload":"` + payload + `"}`
+
+			tempDir := t.TempDir()
+			inputFile := filepath.Join(tempDir, "attestation.json")
+			if err := os.WriteFile(inputFile, []byte(envelope), 0644); err != nil {
+				t.Fatalf("failed to write attestation: %v", err)
+			}
+
+			format, err := DetectSBOMFormat(inputFile)
+			if err != nil {
+				t.Fatalf("DetectSBOMFormat failed: %v", err)
+			}
+			if format != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, format)
+			}
+		})
+	}
+}
+
+func TestWrapInTotoStatement(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "sbom.json")
+	if err := os.WriteFile(inputFile, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644); err != nil {
+		t.Fatalf("failed to write sbom: %v", err)
+	}
+
+	outputFile := filepath.Join(tempDir, "attestation.json")
+	if err := WrapInTotoStatement(context.Background(), inputFile, outputFile, "https://cyclonedx.org/bom", "pkg:test", "deadbeef", ""); err != nil {
+		t.Fatalf("WrapInTotoStatement failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("failed to read attestation: %v", err)
+	}
+	if !isInTotoAttestation(data) {
+		t.Error("expected wrapped output to be detected as an in-toto attestation")
+	}
+}
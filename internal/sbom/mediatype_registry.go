@@ -0,0 +1,247 @@
+// Package sbom provides functionalities to interact with Software Bill of Materials (SBOM).
+package sbom
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// Decoder identifies and decodes the raw bytes of an SBOM document into the
+// provider-agnostic model.Document.
+type Decoder interface {
+	Decode(data []byte) (*model.Document, error)
+}
+
+// Encoder encodes a model.Document back into an SBOM document at a specific
+// spec version.
+type Encoder interface {
+	Encode(doc *model.Document) ([]byte, error)
+}
+
+// DecoderFunc adapts a plain decode function to the Decoder interface.
+type DecoderFunc func(data []byte) (*model.Document, error)
+
+// Decode calls f.
+func (f DecoderFunc) Decode(data []byte) (*model.Document, error) { return f(data) }
+
+// EncoderFunc adapts a plain encode function to the Encoder interface.
+type EncoderFunc func(doc *model.Document) ([]byte, error)
+
+// Encode calls f.
+func (f EncoderFunc) Encode(doc *model.Document) ([]byte, error) { return f(doc) }
+
+// Well-known mediatypes for the spec versions ClickBOM ships built-in
+// support for. Third parties can register additional ones, or override
+// these, with Register.
+const (
+	MediaTypeCycloneDX14 = "application/vnd.cyclonedx+json;version=1.4"
+	MediaTypeCycloneDX15 = "application/vnd.cyclonedx+json;version=1.5"
+	MediaTypeCycloneDX16 = "application/vnd.cyclonedx+json;version=1.6"
+	MediaTypeSPDX22      = "application/spdx+json;version=2.2"
+	MediaTypeSPDX23      = "application/spdx+json;version=2.3"
+)
+
+type registryEntry struct {
+	decoder Decoder
+	encoder Encoder
+}
+
+// Registry maps a fully qualified mediatype (e.g.
+// "application/vnd.cyclonedx+json;version=1.5") to the Decoder/Encoder pair
+// that handles it, so format support isn't limited to what ClickBOM knows
+// about at compile time.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[string]registryEntry
+}
+
+// NewRegistry returns an empty Registry. Most callers don't need one of
+// their own: use the package-level RegisterMediaType, DetectMediaType, and
+// ConvertToMediaType, which all operate on the default Registry ClickBOM
+// registers its own CycloneDX/SPDX support into.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]registryEntry)}
+}
+
+// Register associates mediaType with dec and enc, overwriting any existing
+// registration. Either may be nil if the format only supports one
+// direction.
+func (r *Registry) Register(mediaType string, dec Decoder, enc Encoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[mediaType] = registryEntry{decoder: dec, encoder: enc}
+}
+
+// Decoder returns the Decoder registered for mediaType, if any.
+func (r *Registry) Decoder(mediaType string) (Decoder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[mediaType]
+	if !ok || e.decoder == nil {
+		return nil, false
+	}
+	return e.decoder, true
+}
+
+// Encoder returns the Encoder registered for mediaType, if any.
+func (r *Registry) Encoder(mediaType string) (Encoder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	e, ok := r.entries[mediaType]
+	if !ok || e.encoder == nil {
+		return nil, false
+	}
+	return e.encoder, true
+}
+
+// defaultRegistry is the Registry DetectMediaType and ConvertToMediaType
+// consult. ClickBOM's built-in CycloneDX and SPDX support is registered
+// into it below, so third parties extend the same lookup path ClickBOM
+// itself uses rather than a separate mechanism.
+var defaultRegistry = NewRegistry()
+
+// RegisterMediaType adds dec/enc to the default Registry under mediaType,
+// so DetectMediaType and ConvertToMediaType can find them. Call this from
+// an init() to plug in a format ClickBOM doesn't ship, such as a
+// vendor-specific CycloneDX extension, a future spec version, or a
+// third-party backend like trivy's or grype's own SBOM dialects. Named
+// distinctly from the sbom.Register(name, Constructor) used to add SBOM
+// *sources* (see registry.go) since the two registries serve unrelated
+// extension points.
+func RegisterMediaType(mediaType string, dec Decoder, enc Encoder) {
+	defaultRegistry.Register(mediaType, dec, enc)
+}
+
+func init() {
+	cdxDecoder := DecoderFunc(model.DecodeCycloneDXJSON)
+	for _, mt := range []string{MediaTypeCycloneDX14, MediaTypeCycloneDX15, MediaTypeCycloneDX16} {
+		RegisterMediaType(mt, cdxDecoder, cycloneDXEncoderFor(mediaTypeVersion(mt)))
+	}
+
+	spdxDecoder := DecoderFunc(model.DecodeSPDXJSON)
+	for _, mt := range []string{MediaTypeSPDX22, MediaTypeSPDX23} {
+		RegisterMediaType(mt, spdxDecoder, spdxEncoderFor(mediaTypeVersion(mt)))
+	}
+}
+
+// cycloneDXEncoderFor returns an Encoder that pins doc.SpecVersion to
+// version before delegating to model.EncodeCycloneDXJSON, so encoding
+// through the Registry always produces the requested spec version
+// regardless of what the source document declared.
+func cycloneDXEncoderFor(version string) Encoder {
+	return EncoderFunc(func(doc *model.Document) ([]byte, error) {
+		versioned := *doc
+		versioned.SpecVersion = version
+		return model.EncodeCycloneDXJSON(&versioned)
+	})
+}
+
+// spdxEncoderFor mirrors cycloneDXEncoderFor for SPDX, whose SpecVersion is
+// declared as "SPDX-2.3" rather than a bare version number.
+func spdxEncoderFor(version string) Encoder {
+	return EncoderFunc(func(doc *model.Document) ([]byte, error) {
+		versioned := *doc
+		versioned.SpecVersion = "SPDX-" + version
+		return model.EncodeSPDXJSON(&versioned)
+	})
+}
+
+// mediaTypeFamily returns the mediatype with its ";version=..." parameter
+// stripped, e.g. "application/vnd.cyclonedx+json".
+func mediaTypeFamily(mediaType string) string {
+	family, _, _ := strings.Cut(mediaType, ";")
+	return family
+}
+
+// mediaTypeVersion returns the value of a mediatype's "version" parameter,
+// e.g. "1.5" for "application/vnd.cyclonedx+json;version=1.5".
+func mediaTypeVersion(mediaType string) string {
+	_, params, found := strings.Cut(mediaType, ";")
+	if !found {
+		return ""
+	}
+	const prefix = "version="
+	if strings.HasPrefix(params, prefix) {
+		return strings.TrimPrefix(params, prefix)
+	}
+	return ""
+}
+
+// DetectMediaType is like DetectSBOMFormat but reports the fully qualified
+// mediatype, including the spec version (e.g.
+// "application/vnd.cyclonedx+json;version=1.5"), rather than just the
+// format family. Formats without a versioned mediatype of their own (Syft,
+// SPDX tag-value, in-toto attestations) fall back to their bare Format
+// string.
+func DetectMediaType(filename string) (string, error) {
+	format, err := DetectSBOMFormat(filename)
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case FormatCycloneDX:
+		doc, err := ParseDocument(filename)
+		if err != nil || doc.SpecVersion == "" {
+			return mediaTypeFamily(MediaTypeCycloneDX15), nil
+		}
+		return mediaTypeFamily(MediaTypeCycloneDX15) + ";version=" + doc.SpecVersion, nil
+	case FormatSPDXJSON:
+		doc, err := ParseDocument(filename)
+		if err != nil || doc.SpecVersion == "" {
+			return mediaTypeFamily(MediaTypeSPDX23), nil
+		}
+		return mediaTypeFamily(MediaTypeSPDX23) + ";version=" + strings.TrimPrefix(doc.SpecVersion, "SPDX-"), nil
+	default:
+		return string(format), nil
+	}
+}
+
+// ConvertToMediaType converts inputFile to outputFile by decoding it with
+// the Decoder registered for its detected mediatype and re-encoding with
+// the Encoder registered for targetMediaType, so callers can pin
+// conversion output to an exact spec version (e.g. CycloneDX 1.4, for
+// downstream tools that don't yet handle 1.5) rather than just the format
+// family ConvertSBOM works with.
+func ConvertToMediaType(inputFile, outputFile, targetMediaType string) error {
+	sourceMediaType, err := DetectMediaType(inputFile)
+	if err != nil {
+		return err
+	}
+
+	dec, ok := defaultRegistry.Decoder(sourceMediaType)
+	if !ok {
+		return fmt.Errorf("no decoder registered for mediatype %q; register one with sbom.RegisterMediaType", sourceMediaType)
+	}
+	enc, ok := defaultRegistry.Encoder(targetMediaType)
+	if !ok {
+		return fmt.Errorf("no encoder registered for mediatype %q; register one with sbom.RegisterMediaType", targetMediaType)
+	}
+
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	doc, err := dec.Decode(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s SBOM: %w", sourceMediaType, err)
+	}
+
+	out, err := enc.Encode(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s SBOM: %w", targetMediaType, err)
+	}
+
+	if err := os.WriteFile(outputFile, out, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	logger.Success("SBOM converted successfully (%s -> %s)", sourceMediaType, targetMediaType)
+	return nil
+}
@@ -0,0 +1,64 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+)
+
+func TestDetectMediaTypeReportsSpecVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "sbom.json")
+	if err := os.WriteFile(inputFile, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644); err != nil {
+		t.Fatalf("failed to write sbom: %v", err)
+	}
+
+	mediaType, err := DetectMediaType(inputFile)
+	if err != nil {
+		t.Fatalf("DetectMediaType failed: %v", err)
+	}
+	if mediaType != "application/vnd.cyclonedx+json;version=1.6" {
+		t.Errorf("expected mediatype pinned to the declared spec version, got %s", mediaType)
+	}
+}
+
+func TestConvertToMediaTypePinsTargetVersion(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "sbom.json")
+	if err := os.WriteFile(inputFile, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644); err != nil {
+		t.Fatalf("failed to write sbom: %v", err)
+	}
+	outputFile := filepath.Join(tempDir, "out.json")
+
+	if err := ConvertToMediaType(inputFile, outputFile, MediaTypeCycloneDX14); err != nil {
+		t.Fatalf("ConvertToMediaType failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("failed to read output file: %v", err)
+	}
+	doc, err := model.DecodeCycloneDXJSON(data)
+	if err != nil {
+		t.Fatalf("failed to decode converted output: %v", err)
+	}
+	if doc.SpecVersion != "1.4" {
+		t.Errorf("expected output pinned to CycloneDX 1.4, got %s", doc.SpecVersion)
+	}
+}
+
+func TestRegisterCustomMediaType(t *testing.T) {
+	const customMediaType = "application/vnd.clickbom-test+json;version=1"
+
+	RegisterMediaType(customMediaType, DecoderFunc(model.DecodeCycloneDXJSON), cycloneDXEncoderFor("1.5"))
+	t.Cleanup(func() { defaultRegistry.Register(customMediaType, nil, nil) })
+
+	if _, ok := defaultRegistry.Decoder(customMediaType); !ok {
+		t.Error("expected custom mediatype to have a registered decoder")
+	}
+	if _, ok := defaultRegistry.Encoder(customMediaType); !ok {
+		t.Error("expected custom mediatype to have a registered encoder")
+	}
+}
@@ -8,11 +8,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
+	"github.com/ClickHouse/ClickBOM/internal/httpx"
 	"github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
@@ -28,13 +29,18 @@ type MendClient struct {
 	projectUUIDs string
 	maxWaitTime  int
 	pollInterval int
-	httpClient   *http.Client
-	jwtToken     string
+	httpClient   *http.Client // authenticated API calls; token injected by httpx
+	authClient   *http.Client // unauthenticated login/refresh calls
 }
 
 // NewMendClient creates a new MendClient with the provided configuration.
-func NewMendClient(cfg *config.Config) *MendClient {
-	return &MendClient{
+func NewMendClient(cfg *config.Config) (*MendClient, error) {
+	authClient, err := httpclient.NewClient(httpConfigFrom(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	m := &MendClient{
 		email:        cfg.MendEmail,
 		orgUUID:      cfg.MendOrgUUID,
 		userKey:      cfg.MendUserKey,
@@ -45,13 +51,59 @@ func NewMendClient(cfg *config.Config) *MendClient {
 		projectUUIDs: cfg.MendProjectUUIDs,
 		maxWaitTime:  cfg.MendMaxWaitTime,
 		pollInterval: cfg.MendPollInterval,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Minute,
-		},
+		authClient:   authClient,
+	}
+	m.httpClient = &http.Client{
+		Timeout:   authClient.Timeout,
+		Transport: httpx.NewTokenTransport(authClient.Transport, m.fetchToken),
+	}
+
+	return m, nil
+}
+
+// Name implements Source.
+func (m *MendClient) Name() string { return "mend" }
+
+// Validate implements Source.
+func (m *MendClient) Validate() error {
+	if m.email == "" || m.userKey == "" || m.orgUUID == "" {
+		return fmt.Errorf("mend: email, user key, and org UUID are required")
 	}
+	return nil
 }
 
-func (m *MendClient) authenticate(ctx context.Context) error {
+// Fetch implements Source by requesting and downloading an SBOM export.
+// When more than one project UUID is configured, it exports and merges
+// each project's SBOM via ExportMergedSBOM instead of the single-scope
+// RequestSBOMExport.
+func (m *MendClient) Fetch(ctx context.Context, outputFile string) error {
+	if m.hasMultipleProjects() {
+		return m.ExportMergedSBOM(ctx, outputFile)
+	}
+	return m.RequestSBOMExport(ctx, outputFile)
+}
+
+// hasMultipleProjects reports whether projectUUIDs names more than one
+// project.
+func (m *MendClient) hasMultipleProjects() bool {
+	var count int
+	for _, uuid := range strings.Split(m.projectUUIDs, ",") {
+		if strings.TrimSpace(uuid) != "" {
+			count++
+		}
+	}
+	return count > 1
+}
+
+func init() {
+	Register("mend", func(cfg *config.Config) (Source, error) {
+		return NewMendClient(cfg)
+	})
+}
+
+// fetchToken implements httpx.TokenFetcher, logging into Mend API 3.0 and
+// returning a JWT along with its expiry as parsed from the exp claim.
+func (m *MendClient) fetchToken(ctx context.Context) (string, time.Time, error) {
 	logger.Info("Authenticating with Mend API 3.0")
 
 	loginPayload := map[string]string{
@@ -62,22 +114,22 @@ func (m *MendClient) authenticate(ctx context.Context) error {
 
 	payloadBytes, err := json.Marshal(loginPayload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal login payload: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to marshal login payload: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST",
 		m.baseURL+"/api/v3.0/login",
 		bytes.NewReader(payloadBytes))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := m.httpClient.Do(req)
+	resp, err := m.authClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to authenticate: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to authenticate: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -87,7 +139,7 @@ func (m *MendClient) authenticate(ctx context.Context) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
+		return "", time.Time{}, fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	var loginResp struct {
@@ -98,36 +150,39 @@ func (m *MendClient) authenticate(ctx context.Context) error {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&loginResp); err != nil {
-		return fmt.Errorf("failed to parse login response: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to parse login response: %w", err)
 	}
 
 	// Try to get JWT directly from login response
 	if loginResp.Response.JWTToken != "" {
-		m.jwtToken = loginResp.Response.JWTToken
 		logger.Success("Mend authentication successful")
-		return nil
+		return loginResp.Response.JWTToken, m.jwtExpiry(loginResp.Response.JWTToken), nil
 	}
 
 	// Otherwise use refresh token to get JWT
 	if loginResp.Response.RefreshToken == "" {
-		return fmt.Errorf("no refresh token or JWT token in response")
+		return "", time.Time{}, fmt.Errorf("no refresh token or JWT token in response")
 	}
 
-	// Get JWT token using refresh token
-	req, err = http.NewRequestWithContext(ctx, "POST",
+	return m.refreshJWT(ctx, loginResp.Response.RefreshToken)
+}
+
+// refreshJWT exchanges a Mend refresh token for a fresh JWT.
+func (m *MendClient) refreshJWT(ctx context.Context, refreshToken string) (string, time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST",
 		m.baseURL+"/api/v3.0/login/accessToken",
 		nil)
 	if err != nil {
-		return fmt.Errorf("failed to create JWT request: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to create JWT request: %w", err)
 	}
 
-	req.Header.Set("wss-refresh-token", loginResp.Response.RefreshToken)
+	req.Header.Set("wss-refresh-token", refreshToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err = m.httpClient.Do(req)
+	resp, err := m.authClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to get JWT token: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to get JWT token: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -142,27 +197,32 @@ func (m *MendClient) authenticate(ctx context.Context) error {
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&jwtResp); err != nil {
-		return fmt.Errorf("failed to parse JWT response: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to parse JWT response: %w", err)
 	}
 
 	if jwtResp.Response.JWTToken == "" {
-		return fmt.Errorf("no JWT token in response")
+		return "", time.Time{}, fmt.Errorf("no JWT token in response")
 	}
 
-	m.jwtToken = jwtResp.Response.JWTToken
 	logger.Success("Mend authentication successful")
-	return nil
+	return jwtResp.Response.JWTToken, m.jwtExpiry(jwtResp.Response.JWTToken), nil
+}
+
+// jwtExpiry returns when token expires, falling back to Mend's documented
+// 30-minute JWT lifetime if the exp claim can't be parsed.
+func (m *MendClient) jwtExpiry(token string) time.Time {
+	expiry, err := httpx.ParseJWTExpiry(token)
+	if err != nil {
+		logger.Debug("Could not parse Mend JWT expiry, assuming 30 minutes: %v", err)
+		return time.Now().Add(30 * time.Minute)
+	}
+	return expiry
 }
 
 // RequestSBOMExport requests an SBOM export and downloads it when ready.
 func (m *MendClient) RequestSBOMExport(ctx context.Context, outputFile string) error {
 	logger.Info("Requesting SBOM export from Mend API 3.0")
 
-	// Authenticate first
-	if err := m.authenticate(ctx); err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
-	}
-
 	// Build request payload
 	payload := map[string]interface{}{
 		"name":                   "clickbom-export",
@@ -201,7 +261,6 @@ func (m *MendClient) RequestSBOMExport(ctx context.Context, outputFile string) e
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+m.jwtToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -262,14 +321,6 @@ func (m *MendClient) downloadWhenReady(ctx context.Context, reportUUID, outputFi
 			elapsed := int(time.Since(startTime).Seconds())
 			logger.Info("Checking report status... (elapsed: %ds)", elapsed)
 
-			// Refresh token if needed (every 25 minutes)
-			if elapsed > 0 && elapsed%1500 == 0 {
-				logger.Info("Refreshing JWT token")
-				if err := m.authenticate(ctx); err != nil {
-					logger.Warning("Failed to refresh token: %v", err)
-				}
-			}
-
 			status, err := m.checkReportStatus(ctx, reportUUID)
 			if err != nil {
 				logger.Warning("Failed to check status: %v", err)
@@ -305,7 +356,6 @@ func (m *MendClient) checkReportStatus(ctx context.Context, reportUUID string) (
 		return "", err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+m.jwtToken)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := m.httpClient.Do(req)
@@ -342,41 +392,12 @@ func (m *MendClient) downloadReport(ctx context.Context, reportUUID, outputFile
 		return err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+m.jwtToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := m.httpClient.Do(req)
-	if err != nil {
+	if err := downloadToFile(ctx, m.httpClient, req, outputFile, DownloadOptions{}); err != nil {
 		return fmt.Errorf("download failed: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logger.Warning("Failed to close response body: %v", err)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("download failed (status %d): %s", resp.StatusCode, string(body))
-	}
-
-	// Create output file
-	outFile, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer func() {
-		if err := outFile.Close(); err != nil {
-			logger.Warning("Failed to close file: %v", err)
-		}
-	}()
-
-	// Copy response to file
-	written, err := io.Copy(outFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
 
-	logger.Success("Mend SBOM downloaded successfully (%d bytes)", written)
+	logger.Success("Mend SBOM downloaded successfully")
 	return nil
 }
@@ -0,0 +1,564 @@
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// MergeStrategy selects how Merge reconciles components that key-match
+// across multiple input documents.
+type MergeStrategy string
+
+const (
+	// MergeStrategyUnion keeps every component from every input, unioning
+	// licenses and hashes on key matches and filling in a version, supplier
+	// or CPE that the earlier input left blank. A component holds a single
+	// supplier and CPE, so a differing value from a later input is dropped.
+	// This is the same reconciliation MergeSBOMs has always performed.
+	MergeStrategyUnion MergeStrategy = "union"
+	// MergeStrategyIntersection keeps only components whose key appears in
+	// every input document.
+	MergeStrategyIntersection MergeStrategy = "intersection"
+	// MergeStrategyOverride keeps every component, but on a key match the
+	// later input's fields win instead of only filling in blanks.
+	MergeStrategyOverride MergeStrategy = "override"
+)
+
+// MergeOptions configures Merge.
+type MergeOptions struct {
+	// Strategy selects how key-matching components across inputs are
+	// reconciled. Defaults to MergeStrategyUnion.
+	Strategy MergeStrategy
+	// TargetFormat selects the encoding of the merged output document:
+	// FormatCycloneDX (the default) or FormatSPDXJSON.
+	TargetFormat Format
+	// RootComponentName names the synthetic root component every merged
+	// component is stitched under, representing the final build artifact
+	// (e.g. a container image). Defaults to "merged-artifact".
+	RootComponentName string
+}
+
+// Merge reads the SBOMs at inputs (which may be in different formats),
+// normalizes them through the same model.Document used by ConvertSBOM,
+// merges their components per opts.Strategy, stitches the result under a
+// synthetic root component, and writes the consolidated document to
+// output in opts.TargetFormat. It is the file-based counterpart to
+// MergeSBOMs, intended for folding language-ecosystem SBOMs (Go, npm, pip)
+// produced in earlier CI/CD stages into one final image SBOM.
+func Merge(inputs []string, output string, opts MergeOptions) error {
+	if len(inputs) == 0 {
+		return fmt.Errorf("sbom: no input files to merge")
+	}
+
+	docs := make([]*model.Document, 0, len(inputs))
+	for _, input := range inputs {
+		doc, err := ParseDocument(input)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", input, err)
+		}
+		docs = append(docs, doc)
+	}
+
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = MergeStrategyUnion
+	}
+
+	merged, err := mergeDocuments(docs, strategy)
+	if err != nil {
+		return fmt.Errorf("failed to merge documents: %w", err)
+	}
+
+	rootName := opts.RootComponentName
+	if rootName == "" {
+		rootName = "merged-artifact"
+	}
+	addSyntheticRoot(merged, rootName)
+
+	targetFormat := opts.TargetFormat
+	if targetFormat == "" {
+		targetFormat = FormatCycloneDX
+	}
+
+	var data []byte
+	switch targetFormat {
+	case FormatCycloneDX:
+		merged.Format = "cyclonedx"
+		merged.SpecVersion = "1.5"
+		data, err = model.EncodeCycloneDXJSON(merged)
+	case FormatSPDXJSON:
+		merged.Format = "spdxjson"
+		merged.SpecVersion = "SPDX-2.3"
+		data, err = model.EncodeSPDXJSON(merged)
+	default:
+		return fmt.Errorf("sbom: merge does not support target format %q", targetFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode merged SBOM: %w", err)
+	}
+
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write merged SBOM: %w", err)
+	}
+
+	logger.Success("Merged %d SBOMs into %s (strategy=%s)", len(inputs), output, strategy)
+	return nil
+}
+
+// addSyntheticRoot appends a root component named rootName to doc and adds
+// a dependency edge from it to every component that no other component
+// already depends on (i.e. every graph root across the merged inputs),
+// representing the final artifact that consumes them.
+func addSyntheticRoot(doc *model.Document, rootName string) {
+	dependedOn := make(map[string]bool, len(doc.Components))
+	for _, dep := range doc.Dependencies {
+		for _, on := range dep.DependsOn {
+			dependedOn[on] = true
+		}
+	}
+
+	rootRef := "merged-root"
+	var topLevel []string
+	for _, c := range doc.Components {
+		if !dependedOn[c.ID] {
+			topLevel = append(topLevel, c.ID)
+		}
+	}
+	sort.Strings(topLevel)
+
+	doc.Components = append(doc.Components, model.Component{
+		ID:   rootRef,
+		Name: rootName,
+	})
+	doc.Dependencies = append(doc.Dependencies, model.Dependency{Ref: rootRef, DependsOn: topLevel})
+}
+
+// mergeWorkers bounds how many Mend project exports ExportMergedSBOM runs
+// concurrently.
+const mergeWorkers = 4
+
+// ExportMergedSBOM requests an SBOM export for every project UUID in
+// m.projectUUIDs (fanned out in parallel, bounded by mergeWorkers), parses
+// each into a model.Document, merges them with MergeSBOMs, and writes the
+// resulting CycloneDX 1.5 document to outputFile.
+func (m *MendClient) ExportMergedSBOM(ctx context.Context, outputFile string) error {
+	var uuids []string
+	for _, uuid := range strings.Split(m.projectUUIDs, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			uuids = append(uuids, uuid)
+		}
+	}
+	if len(uuids) == 0 {
+		return fmt.Errorf("mend: no project UUIDs configured for merge")
+	}
+
+	docs := make([]*model.Document, len(uuids))
+	errs := make([]error, len(uuids))
+
+	sem := make(chan struct{}, mergeWorkers)
+	var wg sync.WaitGroup
+	for i, uuid := range uuids {
+		wg.Add(1)
+		go func(i int, uuid string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			doc, err := m.exportProjectDocument(ctx, uuid)
+			if err != nil {
+				errs[i] = fmt.Errorf("project %s: %w", uuid, err)
+				return
+			}
+			docs[i] = doc
+		}(i, uuid)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to export one or more Mend projects: %w", err)
+		}
+	}
+
+	merged, err := MergeSBOMs(docs...)
+	if err != nil {
+		return fmt.Errorf("failed to merge SBOMs: %w", err)
+	}
+
+	data, err := model.EncodeCycloneDXJSON(merged)
+	if err != nil {
+		return fmt.Errorf("failed to encode merged SBOM: %w", err)
+	}
+
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write merged SBOM: %w", err)
+	}
+
+	logger.Success("Merged SBOM from %d Mend projects written to %s", len(uuids), outputFile)
+	return nil
+}
+
+// exportProjectDocument requests and downloads a single project-scoped
+// SBOM export, parses it into a model.Document, and tags each component
+// with its source project UUID for provenance.
+func (m *MendClient) exportProjectDocument(ctx context.Context, projectUUID string) (*model.Document, error) {
+	tmpFile, err := os.CreateTemp("", "clickbom-mend-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	tmpFile.Close()
+	defer os.Remove(tmpPath)
+
+	if err := m.requestProjectExport(ctx, projectUUID, tmpPath); err != nil {
+		return nil, err
+	}
+
+	doc, err := ParseDocument(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range doc.Components {
+		if doc.Components[i].Properties == nil {
+			doc.Components[i].Properties = map[string]string{}
+		}
+		doc.Components[i].Properties["clickbom:source-project"] = projectUUID
+	}
+
+	return doc, nil
+}
+
+// requestProjectExport requests and downloads a project-scoped SBOM
+// export for a single project UUID, independent of the client's
+// configured default scope (m.projectUUID/m.productUUID/m.orgScopeUUID).
+func (m *MendClient) requestProjectExport(ctx context.Context, projectUUID, outputFile string) error {
+	logger.Info("Requesting SBOM export from Mend API 3.0 for project %s", projectUUID)
+
+	payload := map[string]interface{}{
+		"name":                   "clickbom-export",
+		"reportType":             "cycloneDX_1_5",
+		"format":                 "json",
+		"includeVulnerabilities": false,
+		"scopeType":              "project",
+		"scopeUuid":              projectUUID,
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/api/v3.0/projects/%s/dependencies/reports/SBOM", m.baseURL, projectUUID)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request SBOM export: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("export request failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var exportResp struct {
+		Response struct {
+			UUID string `json:"uuid"`
+		} `json:"response"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&exportResp); err != nil {
+		return fmt.Errorf("failed to parse export response: %w", err)
+	}
+
+	if exportResp.Response.UUID == "" {
+		return fmt.Errorf("no report UUID in response")
+	}
+
+	logger.Info("Report UUID for project %s: %s", projectUUID, exportResp.Response.UUID)
+
+	return m.downloadWhenReady(ctx, exportResp.Response.UUID, outputFile)
+}
+
+// componentKey returns a stable dedup key for a component: PURL, CPE, or
+// name+version, in that preference order.
+func componentKey(c model.Component) string {
+	switch {
+	case c.PURL != "":
+		return "purl:" + c.PURL
+	case c.CPE != "":
+		return "cpe:" + c.CPE
+	default:
+		return "name:" + c.Name + "@" + c.Version
+	}
+}
+
+// MergeSBOMs merges components across docs by PURL/CPE (falling back to
+// name+version), unioning licenses and provenance properties, and
+// rewrites the dependency graph onto a new set of stable BOM-refs
+// (assigned in sorted component-key order, so the same inputs always
+// produce the same refs). The result is a single CycloneDX 1.5 document.
+// It is equivalent to mergeDocuments(docs, MergeStrategyUnion).
+func MergeSBOMs(docs ...*model.Document) (*model.Document, error) {
+	return mergeDocuments(docs, MergeStrategyUnion)
+}
+
+// mergeDocuments is the shared implementation behind MergeSBOMs and Merge.
+// It dedups components by componentKey, reconciles key-matching components
+// per strategy, rewrites the dependency graph onto a new set of stable
+// BOM-refs (assigned in sorted component-key order, so the same inputs
+// always produce the same refs), and retains the earliest non-empty
+// metadata timestamp across docs.
+func mergeDocuments(docs []*model.Document, strategy MergeStrategy) (*model.Document, error) {
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("sbom: no documents to merge")
+	}
+
+	merged := map[string]*model.Component{}
+	var order []string
+	docCount := map[string]int{}
+	// refIndex maps docIndex -> original document-local ref -> component
+	// key, so dependency edges can be rewritten onto the merged set.
+	refIndex := make([]map[string]string, len(docs))
+	var earliestTimestamp string
+
+	for di, doc := range docs {
+		if doc == nil {
+			return nil, fmt.Errorf("sbom: nil document at index %d", di)
+		}
+		if ts := doc.Metadata.Timestamp; ts != "" && (earliestTimestamp == "" || ts < earliestTimestamp) {
+			earliestTimestamp = ts
+		}
+		refIndex[di] = make(map[string]string, len(doc.Components))
+		seenInDoc := map[string]bool{}
+
+		for _, c := range doc.Components {
+			key := componentKey(c)
+			refIndex[di][c.ID] = key
+			if !seenInDoc[key] {
+				seenInDoc[key] = true
+				docCount[key]++
+			}
+
+			existing, ok := merged[key]
+			if !ok {
+				clone := c
+				clone.Licenses = append([]string(nil), c.Licenses...)
+				clone.Properties = cloneProperties(c.Properties)
+				clone.Hashes = cloneHashes(c.Hashes)
+				merged[key] = &clone
+				order = append(order, key)
+				continue
+			}
+
+			mergeComponentInto(existing, c, strategy)
+		}
+	}
+
+	if strategy == MergeStrategyIntersection {
+		kept := order[:0]
+		for _, key := range order {
+			if docCount[key] == len(docs) {
+				kept = append(kept, key)
+			} else {
+				delete(merged, key)
+			}
+		}
+		order = kept
+	}
+
+	sort.Strings(order)
+
+	newRefs := make(map[string]string, len(order))
+	components := make([]model.Component, 0, len(order))
+	for i, key := range order {
+		ref := fmt.Sprintf("merged-%d", i+1)
+		newRefs[key] = ref
+		comp := *merged[key]
+		comp.ID = ref
+		components = append(components, comp)
+	}
+
+	depSets := map[string]map[string]bool{}
+	for di, doc := range docs {
+		for _, dep := range doc.Dependencies {
+			fromKey, ok := refIndex[di][dep.Ref]
+			if !ok {
+				continue
+			}
+			fromRef, ok := newRefs[fromKey]
+			if !ok {
+				continue
+			}
+			for _, on := range dep.DependsOn {
+				onKey, ok := refIndex[di][on]
+				if !ok {
+					continue
+				}
+				onRef, ok := newRefs[onKey]
+				if !ok {
+					continue
+				}
+				if depSets[fromRef] == nil {
+					depSets[fromRef] = map[string]bool{}
+				}
+				depSets[fromRef][onRef] = true
+			}
+		}
+	}
+
+	depRefs := make([]string, 0, len(depSets))
+	for ref := range depSets {
+		depRefs = append(depRefs, ref)
+	}
+	sort.Strings(depRefs)
+
+	dependencies := make([]model.Dependency, 0, len(depRefs))
+	for _, ref := range depRefs {
+		set := depSets[ref]
+		dependsOn := make([]string, 0, len(set))
+		for on := range set {
+			dependsOn = append(dependsOn, on)
+		}
+		sort.Strings(dependsOn)
+		dependencies = append(dependencies, model.Dependency{Ref: ref, DependsOn: dependsOn})
+	}
+
+	merged2 := &model.Document{
+		Format:       "cyclonedx",
+		SpecVersion:  "1.5",
+		Components:   components,
+		Dependencies: dependencies,
+	}
+	merged2.Metadata.Timestamp = earliestTimestamp
+	return merged2, nil
+}
+
+// mergeComponentInto folds c into existing (a prior component sharing the
+// same componentKey) per strategy. Licenses and hashes are always unioned,
+// and c's properties are copied in, except that a differing
+// "clickbom:source-project" value is appended comma-separated so every
+// source project is kept. MergeStrategyOverride additionally replaces
+// existing's version/supplier/CPE with c's whenever c sets them, instead of
+// only filling in blanks.
+func mergeComponentInto(existing *model.Component, c model.Component, strategy MergeStrategy) {
+	existing.Licenses = unionStrings(existing.Licenses, c.Licenses)
+	existing.Hashes = unionHashes(existing.Hashes, c.Hashes)
+	for k, v := range c.Properties {
+		if existing.Properties == nil {
+			existing.Properties = map[string]string{}
+		}
+		if old := existing.Properties[k]; k == "clickbom:source-project" && old != "" {
+			if !containsString(strings.Split(old, ","), v) {
+				existing.Properties[k] = old + "," + v
+			}
+			continue
+		}
+		existing.Properties[k] = v
+	}
+
+	if strategy == MergeStrategyOverride {
+		if c.Version != "" {
+			existing.Version = c.Version
+		}
+		if c.Supplier != "" {
+			existing.Supplier = c.Supplier
+		}
+		if c.CPE != "" {
+			existing.CPE = c.CPE
+		}
+		return
+	}
+
+	if existing.Version == "" {
+		existing.Version = c.Version
+	}
+	if existing.Supplier == "" {
+		existing.Supplier = c.Supplier
+	}
+	if existing.CPE == "" {
+		existing.CPE = c.CPE
+	}
+}
+
+// unionStrings returns the elements of a followed by the elements of b
+// that aren't already in a, preserving a's order.
+func unionStrings(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	out := append([]string(nil), a...)
+	for _, v := range a {
+		seen[v] = true
+	}
+	for _, v := range b {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func cloneProperties(p map[string]string) map[string]string {
+	if p == nil {
+		return nil
+	}
+	out := make(map[string]string, len(p))
+	for k, v := range p {
+		out[k] = v
+	}
+	return out
+}
+
+// cloneHashes returns a shallow copy of h.
+func cloneHashes(h map[string]string) map[string]string {
+	if h == nil {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	return out
+}
+
+// unionHashes returns a, with any algorithms from b it doesn't already
+// have added in.
+func unionHashes(a, b map[string]string) map[string]string {
+	if len(b) == 0 {
+		return a
+	}
+	out := a
+	if out == nil {
+		out = map[string]string{}
+	}
+	for algo, value := range b {
+		if _, ok := out[algo]; !ok {
+			out[algo] = value
+		}
+	}
+	return out
+}
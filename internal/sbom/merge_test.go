@@ -0,0 +1,185 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+)
+
+func TestMergeSBOMsDedupesByPURL(t *testing.T) {
+	docA := &model.Document{
+		Components: []model.Component{
+			{ID: "a1", Name: "left-pad", Version: "1.0.0", PURL: "pkg:npm/left-pad@1.0.0", Licenses: []string{"MIT"}},
+		},
+		Dependencies: []model.Dependency{
+			{Ref: "a1", DependsOn: nil},
+		},
+	}
+	docB := &model.Document{
+		Components: []model.Component{
+			{ID: "b1", Name: "left-pad", Version: "1.0.0", PURL: "pkg:npm/left-pad@1.0.0", Licenses: []string{"Apache-2.0"}},
+			{ID: "b2", Name: "right-pad", Version: "2.0.0", PURL: "pkg:npm/right-pad@2.0.0"},
+		},
+		Dependencies: []model.Dependency{
+			{Ref: "b2", DependsOn: []string{"b1"}},
+		},
+	}
+
+	merged, err := MergeSBOMs(docA, docB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if merged.Format != "cyclonedx" || merged.SpecVersion != "1.5" {
+		t.Errorf("unexpected format/version: %s %s", merged.Format, merged.SpecVersion)
+	}
+
+	if len(merged.Components) != 2 {
+		t.Fatalf("expected 2 deduped components, got %d: %+v", len(merged.Components), merged.Components)
+	}
+
+	var leftPad *model.Component
+	for i := range merged.Components {
+		if merged.Components[i].Name == "left-pad" {
+			leftPad = &merged.Components[i]
+		}
+	}
+	if leftPad == nil {
+		t.Fatal("left-pad not found in merged components")
+	}
+	if len(leftPad.Licenses) != 2 {
+		t.Errorf("expected licenses to be unioned, got %v", leftPad.Licenses)
+	}
+
+	if len(merged.Dependencies) != 1 {
+		t.Fatalf("expected 1 dependency edge, got %d: %+v", len(merged.Dependencies), merged.Dependencies)
+	}
+}
+
+func TestMergeSBOMsPreservesProvenance(t *testing.T) {
+	docA := &model.Document{
+		Components: []model.Component{
+			{ID: "a1", Name: "comp", Version: "1.0.0", PURL: "pkg:npm/comp@1.0.0", Properties: map[string]string{"clickbom:source-project": "proj-a"}},
+		},
+	}
+	docB := &model.Document{
+		Components: []model.Component{
+			{ID: "b1", Name: "comp", Version: "1.0.0", PURL: "pkg:npm/comp@1.0.0", Properties: map[string]string{"clickbom:source-project": "proj-b"}},
+		},
+	}
+
+	merged, err := MergeSBOMs(docA, docB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged.Components) != 1 {
+		t.Fatalf("expected 1 deduped component, got %d", len(merged.Components))
+	}
+	if merged.Components[0].Properties["clickbom:source-project"] == "" {
+		t.Error("expected provenance property to be preserved")
+	}
+}
+
+func TestMergeSBOMsNoDocuments(t *testing.T) {
+	if _, err := MergeSBOMs(); err == nil {
+		t.Fatal("expected an error when merging zero documents")
+	}
+}
+
+func TestMergeSBOMsStableRefs(t *testing.T) {
+	doc := &model.Document{
+		Components: []model.Component{
+			{ID: "x", Name: "zeta", Version: "1.0.0"},
+			{ID: "y", Name: "alpha", Version: "1.0.0"},
+		},
+	}
+
+	first, err := MergeSBOMs(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := MergeSBOMs(doc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range first.Components {
+		if first.Components[i].ID != second.Components[i].ID {
+			t.Errorf("expected stable refs across merges, got %s vs %s", first.Components[i].ID, second.Components[i].ID)
+		}
+	}
+}
+
+func TestMergeDocumentsIntersectionDropsUniqueComponents(t *testing.T) {
+	docA := &model.Document{
+		Components: []model.Component{
+			{ID: "a1", Name: "shared", Version: "1.0.0", PURL: "pkg:npm/shared@1.0.0"},
+			{ID: "a2", Name: "only-in-a", Version: "1.0.0", PURL: "pkg:npm/only-in-a@1.0.0"},
+		},
+	}
+	docB := &model.Document{
+		Components: []model.Component{
+			{ID: "b1", Name: "shared", Version: "1.0.0", PURL: "pkg:npm/shared@1.0.0"},
+		},
+	}
+
+	merged, err := mergeDocuments([]*model.Document{docA, docB}, MergeStrategyIntersection)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged.Components) != 1 || merged.Components[0].Name != "shared" {
+		t.Fatalf("expected only the shared component to survive intersection, got %+v", merged.Components)
+	}
+}
+
+func TestMergeDocumentsOverrideReplacesFields(t *testing.T) {
+	docA := &model.Document{
+		Components: []model.Component{
+			{ID: "a1", Name: "comp", Version: "1.0.0", PURL: "pkg:npm/comp@1.0.0", Supplier: "supplier-a"},
+		},
+	}
+	docB := &model.Document{
+		Components: []model.Component{
+			{ID: "b1", Name: "comp", Version: "2.0.0", PURL: "pkg:npm/comp@1.0.0", Supplier: "supplier-b"},
+		},
+	}
+
+	merged, err := mergeDocuments([]*model.Document{docA, docB}, MergeStrategyOverride)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged.Components) != 1 {
+		t.Fatalf("expected 1 deduped component, got %d", len(merged.Components))
+	}
+	if merged.Components[0].Version != "2.0.0" || merged.Components[0].Supplier != "supplier-b" {
+		t.Errorf("expected override strategy to take the later document's fields, got %+v", merged.Components[0])
+	}
+}
+
+func TestAddSyntheticRoot(t *testing.T) {
+	doc := &model.Document{
+		Components: []model.Component{
+			{ID: "a", Name: "leaf"},
+			{ID: "b", Name: "root-of-graph"},
+		},
+		Dependencies: []model.Dependency{
+			{Ref: "b", DependsOn: []string{"a"}},
+		},
+	}
+
+	addSyntheticRoot(doc, "final-artifact")
+
+	if len(doc.Components) != 3 {
+		t.Fatalf("expected synthetic root to be appended, got %d components", len(doc.Components))
+	}
+
+	var rootDeps []string
+	for _, d := range doc.Dependencies {
+		if d.Ref == "merged-root" {
+			rootDeps = d.DependsOn
+		}
+	}
+	if len(rootDeps) != 1 || rootDeps[0] != "b" {
+		t.Errorf("expected synthetic root to depend only on the top-level component, got %v", rootDeps)
+	}
+}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// cyclonedxDocument mirrors the subset of the CycloneDX 1.4/1.5/1.6 JSON
+// schema needed to populate a Document; unrecognized fields are ignored.
+type cyclonedxDocument struct {
+	BOMFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Timestamp string `json:"timestamp"`
+		Authors   []struct {
+			Name string `json:"name"`
+		} `json:"authors"`
+		Tools struct {
+			Components []struct {
+				Name string `json:"name"`
+			} `json:"components"`
+		} `json:"tools"`
+	} `json:"metadata"`
+	Components []cyclonedxComponent `json:"components"`
+	Dependencies []struct {
+		Ref       string   `json:"ref"`
+		DependsOn []string `json:"dependsOn"`
+	} `json:"dependencies"`
+	Vulnerabilities []struct {
+		ID      string `json:"id"`
+		Source  struct {
+			Name string `json:"name"`
+		} `json:"source"`
+		Ratings []struct {
+			Severity string `json:"severity"`
+		} `json:"ratings"`
+		Affects []struct {
+			Ref string `json:"ref"`
+		} `json:"affects"`
+	} `json:"vulnerabilities"`
+}
+
+type cyclonedxComponent struct {
+	BOMRef  string `json:"bom-ref"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Supplier struct {
+		Name string `json:"name"`
+	} `json:"supplier"`
+	PURL     string `json:"purl"`
+	CPE      string `json:"cpe"`
+	Licenses []struct {
+		License struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"license"`
+		Expression string `json:"expression"`
+	} `json:"licenses"`
+	Hashes []struct {
+		Alg     string `json:"alg"`
+		Content string `json:"content"`
+	} `json:"hashes"`
+	Properties []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+// cyclonedxOutDocument mirrors cyclonedxDocument but only carries the
+// fields EncodeCycloneDXJSON populates; its JSON layout matches what
+// DecodeCycloneDXJSON accepts.
+type cyclonedxOutDocument struct {
+	BOMFormat    string                 `json:"bomFormat"`
+	SpecVersion  string                 `json:"specVersion"`
+	Components   []cyclonedxOutComponent `json:"components,omitempty"`
+	Dependencies []cyclonedxOutDependency `json:"dependencies,omitempty"`
+}
+
+type cyclonedxOutComponent struct {
+	BOMRef     string                 `json:"bom-ref"`
+	Name       string                 `json:"name"`
+	Version    string                 `json:"version,omitempty"`
+	PURL       string                 `json:"purl,omitempty"`
+	CPE        string                 `json:"cpe,omitempty"`
+	Licenses   []cyclonedxOutLicense  `json:"licenses,omitempty"`
+	Properties []cyclonedxOutProperty `json:"properties,omitempty"`
+}
+
+type cyclonedxOutLicense struct {
+	License cyclonedxOutLicenseID `json:"license"`
+}
+
+type cyclonedxOutLicenseID struct {
+	ID string `json:"id"`
+}
+
+type cyclonedxOutProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cyclonedxOutDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// EncodeCycloneDXJSON serializes doc as a CycloneDX JSON document, using
+// doc.SpecVersion as the declared specVersion (callers that build merged
+// documents should set this to "1.5"). Each component is emitted with its
+// bom-ref, name, version, purl, CPE, licenses, and properties, followed by
+// the dependency graph; component suppliers and hashes, vulnerabilities,
+// and document-level metadata are not emitted.
+func EncodeCycloneDXJSON(doc *Document) ([]byte, error) {
+	out := cyclonedxOutDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: doc.SpecVersion,
+	}
+
+	for _, c := range doc.Components {
+		oc := cyclonedxOutComponent{
+			BOMRef:  c.ID,
+			Name:    c.Name,
+			Version: c.Version,
+			PURL:    c.PURL,
+			CPE:     c.CPE,
+		}
+		for _, license := range c.Licenses {
+			oc.Licenses = append(oc.Licenses, cyclonedxOutLicense{License: cyclonedxOutLicenseID{ID: license}})
+		}
+		for name, value := range c.Properties {
+			oc.Properties = append(oc.Properties, cyclonedxOutProperty{Name: name, Value: value})
+		}
+		sort.Slice(oc.Properties, func(i, j int) bool { return oc.Properties[i].Name < oc.Properties[j].Name })
+		out.Components = append(out.Components, oc)
+	}
+
+	for _, d := range doc.Dependencies {
+		out.Dependencies = append(out.Dependencies, cyclonedxOutDependency{Ref: d.Ref, DependsOn: d.DependsOn})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("model: failed to marshal CycloneDX document: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeCycloneDXJSON decodes a CycloneDX 1.4/1.5/1.6 JSON document into a
+// Document.
+func DecodeCycloneDXJSON(data []byte) (*Document, error) {
+	var cdx cyclonedxDocument
+	if err := json.Unmarshal(data, &cdx); err != nil {
+		return nil, fmt.Errorf("model: failed to parse CycloneDX JSON: %w", err)
+	}
+	if cdx.BOMFormat != "CycloneDX" {
+		return nil, fmt.Errorf("model: not a CycloneDX document (bomFormat=%q)", cdx.BOMFormat)
+	}
+
+	doc := &Document{
+		Format:      "cyclonedx",
+		SpecVersion: cdx.SpecVersion,
+	}
+
+	if cdx.Metadata.Timestamp != "" {
+		doc.Metadata.Timestamp = cdx.Metadata.Timestamp
+	}
+	if len(cdx.Metadata.Authors) > 0 {
+		doc.Metadata.Author = cdx.Metadata.Authors[0].Name
+	}
+	if len(cdx.Metadata.Tools.Components) > 0 {
+		doc.Metadata.Tool = cdx.Metadata.Tools.Components[0].Name
+	}
+
+	for _, c := range cdx.Components {
+		comp := Component{
+			ID:       c.BOMRef,
+			Name:     c.Name,
+			Version:  c.Version,
+			Supplier: c.Supplier.Name,
+			PURL:     c.PURL,
+			CPE:      c.CPE,
+		}
+		for _, l := range c.Licenses {
+			if l.Expression != "" {
+				// Per the CycloneDX spec, an expression entry is mutually
+				// exclusive with id/name entries in the same licenses array.
+				comp.Licenses = []string{l.Expression}
+				break
+			}
+			name := l.License.ID
+			if name == "" {
+				name = l.License.Name
+			}
+			if name != "" {
+				comp.Licenses = append(comp.Licenses, name)
+			}
+		}
+		if len(c.Hashes) > 0 {
+			comp.Hashes = make(map[string]string, len(c.Hashes))
+			for _, h := range c.Hashes {
+				comp.Hashes[h.Alg] = h.Content
+			}
+		}
+		if len(c.Properties) > 0 {
+			comp.Properties = make(map[string]string, len(c.Properties))
+			for _, p := range c.Properties {
+				comp.Properties[p.Name] = p.Value
+			}
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	for _, d := range cdx.Dependencies {
+		doc.Dependencies = append(doc.Dependencies, Dependency{
+			Ref:       d.Ref,
+			DependsOn: d.DependsOn,
+		})
+	}
+
+	for _, v := range cdx.Vulnerabilities {
+		vuln := Vulnerability{
+			ID:     v.ID,
+			Source: v.Source.Name,
+		}
+		if len(v.Ratings) > 0 {
+			vuln.Severity = v.Ratings[0].Severity
+		}
+		for _, a := range v.Affects {
+			vuln.Affects = append(vuln.Affects, a.Ref)
+		}
+		doc.Vulnerabilities = append(doc.Vulnerabilities, vuln)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// cyclonedxXMLDocument mirrors the subset of the CycloneDX 1.4/1.5/1.6 XML
+// schema needed to populate a Document; unrecognized elements are ignored.
+// The XML and JSON schemas share the same logical shape, so this type
+// mirrors cyclonedxDocument field-for-field.
+type cyclonedxXMLDocument struct {
+	XMLName     xml.Name `xml:"bom"`
+	SpecVersion string   `xml:"version,attr"`
+	Metadata    struct {
+		Timestamp string `xml:"timestamp"`
+		Authors   struct {
+			Author []struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+		} `xml:"authors"`
+		Tools struct {
+			Components struct {
+				Component []struct {
+					Name string `xml:"name"`
+				} `xml:"component"`
+			} `xml:"components"`
+		} `xml:"tools"`
+	} `xml:"metadata"`
+	Components struct {
+		Component []cyclonedxXMLComponent `xml:"component"`
+	} `xml:"components"`
+	Dependencies struct {
+		Dependency []struct {
+			Ref        string `xml:"ref,attr"`
+			Dependency []struct {
+				Ref string `xml:"ref,attr"`
+			} `xml:"dependency"`
+		} `xml:"dependency"`
+	} `xml:"dependencies"`
+}
+
+type cyclonedxXMLComponent struct {
+	BOMRef   string `xml:"bom-ref,attr"`
+	Name     string `xml:"name"`
+	Version  string `xml:"version"`
+	Supplier struct {
+		Name string `xml:"name"`
+	} `xml:"supplier"`
+	PURL     string `xml:"purl"`
+	CPE      string `xml:"cpe"`
+	Licenses struct {
+		License []struct {
+			ID   string `xml:"id"`
+			Name string `xml:"name"`
+		} `xml:"license"`
+	} `xml:"licenses"`
+	Hashes struct {
+		Hash []struct {
+			Alg     string `xml:"alg,attr"`
+			Content string `xml:",chardata"`
+		} `xml:"hash"`
+	} `xml:"hashes"`
+}
+
+// DecodeCycloneDXXML decodes a CycloneDX 1.4/1.5/1.6 XML document into a
+// Document, using the same field mapping as DecodeCycloneDXJSON.
+func DecodeCycloneDXXML(data []byte) (*Document, error) {
+	var cdx cyclonedxXMLDocument
+	if err := xml.Unmarshal(data, &cdx); err != nil {
+		return nil, fmt.Errorf("model: failed to parse CycloneDX XML: %w", err)
+	}
+	if cdx.XMLName.Local != "bom" {
+		return nil, fmt.Errorf("model: not a CycloneDX document (root element %q)", cdx.XMLName.Local)
+	}
+
+	doc := &Document{
+		Format:      "cyclonedx-xml",
+		SpecVersion: cdx.SpecVersion,
+	}
+
+	doc.Metadata.Timestamp = cdx.Metadata.Timestamp
+	if len(cdx.Metadata.Authors.Author) > 0 {
+		doc.Metadata.Author = cdx.Metadata.Authors.Author[0].Name
+	}
+	if len(cdx.Metadata.Tools.Components.Component) > 0 {
+		doc.Metadata.Tool = cdx.Metadata.Tools.Components.Component[0].Name
+	}
+
+	for _, c := range cdx.Components.Component {
+		comp := Component{
+			ID:       c.BOMRef,
+			Name:     c.Name,
+			Version:  c.Version,
+			Supplier: c.Supplier.Name,
+			PURL:     c.PURL,
+			CPE:      c.CPE,
+		}
+		for _, l := range c.Licenses.License {
+			name := l.ID
+			if name == "" {
+				name = l.Name
+			}
+			if name != "" {
+				comp.Licenses = append(comp.Licenses, name)
+			}
+		}
+		if len(c.Hashes.Hash) > 0 {
+			comp.Hashes = make(map[string]string, len(c.Hashes.Hash))
+			for _, h := range c.Hashes.Hash {
+				comp.Hashes[h.Alg] = h.Content
+			}
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	for _, d := range cdx.Dependencies.Dependency {
+		dep := Dependency{Ref: d.Ref}
+		for _, child := range d.Dependency {
+			dep.DependsOn = append(dep.DependsOn, child.Ref)
+		}
+		doc.Dependencies = append(doc.Dependencies, dep)
+	}
+
+	return doc, nil
+}
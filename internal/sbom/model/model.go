@@ -0,0 +1,92 @@
+// Package model defines a provider-agnostic in-memory representation of an
+// SBOM document, along with decoders for the CycloneDX and SPDX formats.
+// Source clients and downstream consumers (merging, diffing, ClickHouse
+// ingestion) work against Document rather than treating SBOMs as opaque
+// JSON blobs.
+package model
+
+import "fmt"
+
+// Document is a provider-agnostic view of an SBOM: its components, the
+// dependency edges between them, any vulnerabilities reported against
+// them, and document-level metadata.
+type Document struct {
+	Format          string
+	SpecVersion     string
+	Metadata        Metadata
+	Components      []Component
+	Dependencies    []Dependency
+	Vulnerabilities []Vulnerability
+	// LicenseRefs maps SPDX "LicenseRef-*" identifiers to their human
+	// readable name, from the document's extracted licensing info
+	// (SPDX JSON's hasExtractedLicensingInfos, or tag-value's
+	// LicenseID/LicenseName pairs). Nil for formats without custom
+	// license references, such as CycloneDX.
+	LicenseRefs map[string]string
+}
+
+// Metadata holds document-level information common to both CycloneDX and
+// SPDX: the tool that produced it, when, and who authored it.
+type Metadata struct {
+	Tool      string
+	Author    string
+	Timestamp string
+}
+
+// Component is a single package/library/file tracked by the SBOM.
+type Component struct {
+	// ID is the document-local identifier (CycloneDX bom-ref or SPDX
+	// SPDXID) used to resolve Dependency and Vulnerability references.
+	ID       string
+	Name     string
+	Version  string
+	Supplier string
+	PURL     string
+	CPE      string
+	Licenses []string
+	Hashes   map[string]string
+	// Properties carries free-form key/value annotations, such as
+	// per-source provenance attached when merging documents from
+	// multiple scans (see sbom.MergeSBOMs).
+	Properties map[string]string
+}
+
+// Dependency is a single "ref depends on these other refs" edge, keyed by
+// Component.ID.
+type Dependency struct {
+	Ref       string
+	DependsOn []string
+}
+
+// Vulnerability is a known vulnerability reported against one or more
+// components, identified by Component.ID in Affects.
+type Vulnerability struct {
+	ID       string
+	Source   string
+	Severity string
+	Affects  []string
+}
+
+// Decode parses data into a Document according to format, one of
+// "cyclonedx", "cyclonedx-xml", "spdxjson", "spdx-tagvalue", or "syft"
+// (mirroring the format strings used by score.ScoreSBOM). The names
+// "cyclonedx-protobuf" and "spdx3" are recognized but have no decoder and
+// return an error.
+func Decode(data []byte, format string) (*Document, error) {
+	switch format {
+	case "cyclonedx":
+		return DecodeCycloneDXJSON(data)
+	case "cyclonedx-xml":
+		return DecodeCycloneDXXML(data)
+	case "spdxjson":
+		return DecodeSPDXJSON(data)
+	case "spdx-tagvalue":
+		return DecodeSPDXTagValue(data)
+	case "syft":
+		return DecodeSyftJSON(data)
+	case "cyclonedx-protobuf", "spdx3":
+		return nil, fmt.Errorf("model: decoding %q is not yet implemented", format)
+	default:
+		return nil, fmt.Errorf("model: unsupported format: %s", format)
+	}
+}
@@ -0,0 +1,202 @@
+package model
+
+import "testing"
+
+func TestDecodeCycloneDXJSON(t *testing.T) {
+	data := []byte(`{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"metadata": {
+			"timestamp": "2024-01-01T00:00:00Z",
+			"authors": [{"name": "Acme CI"}]
+		},
+		"components": [
+			{"bom-ref": "pkg:comp-a", "name": "comp-a", "version": "1.0.0", "purl": "pkg:npm/comp-a@1.0.0", "licenses": [{"license": {"id": "MIT"}}]}
+		],
+		"dependencies": [
+			{"ref": "pkg:comp-a", "dependsOn": []}
+		],
+		"vulnerabilities": [
+			{"id": "CVE-2024-0001", "source": {"name": "NVD"}, "ratings": [{"severity": "high"}], "affects": [{"ref": "pkg:comp-a"}]}
+		]
+	}`)
+
+	doc, err := DecodeCycloneDXJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Format != "cyclonedx" || doc.SpecVersion != "1.5" {
+		t.Errorf("unexpected format/version: %s %s", doc.Format, doc.SpecVersion)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Name != "comp-a" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+	if len(doc.Vulnerabilities) != 1 || doc.Vulnerabilities[0].Severity != "high" {
+		t.Fatalf("unexpected vulnerabilities: %+v", doc.Vulnerabilities)
+	}
+}
+
+func TestDecodeCycloneDXJSONWrongFormat(t *testing.T) {
+	if _, err := DecodeCycloneDXJSON([]byte(`{"spdxVersion": "SPDX-2.3"}`)); err == nil {
+		t.Fatal("expected an error for a non-CycloneDX document")
+	}
+}
+
+func TestDecodeSPDXJSON(t *testing.T) {
+	data := []byte(`{
+		"spdxVersion": "SPDX-2.3",
+		"SPDXID": "SPDXRef-DOCUMENT",
+		"creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: syft"]},
+		"packages": [
+			{"SPDXID": "SPDXRef-comp-a", "name": "comp-a", "versionInfo": "1.0.0", "licenseConcluded": "Apache-2.0", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/comp-a@1.0.0"}]}
+		],
+		"relationships": [
+			{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-comp-a"}
+		]
+	}`)
+
+	doc, err := DecodeSPDXJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Format != "spdxjson" || doc.SpecVersion != "SPDX-2.3" {
+		t.Errorf("unexpected format/version: %s %s", doc.Format, doc.SpecVersion)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Licenses[0] != "Apache-2.0" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+	if len(doc.Dependencies) != 1 || doc.Dependencies[0].Ref != "SPDXRef-DOCUMENT" {
+		t.Fatalf("unexpected dependencies: %+v", doc.Dependencies)
+	}
+}
+
+func TestDecodeCycloneDXXML(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<bom xmlns="http://cyclonedx.org/schema/bom/1.5" version="1">
+  <metadata>
+    <timestamp>2024-01-01T00:00:00Z</timestamp>
+    <authors><author><name>Acme CI</name></author></authors>
+  </metadata>
+  <components>
+    <component type="library" bom-ref="pkg:comp-a">
+      <name>comp-a</name>
+      <version>1.0.0</version>
+      <purl>pkg:npm/comp-a@1.0.0</purl>
+      <licenses><license><id>MIT</id></license></licenses>
+    </component>
+  </components>
+  <dependencies>
+    <dependency ref="pkg:comp-a"/>
+  </dependencies>
+</bom>`)
+
+	doc, err := DecodeCycloneDXXML(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Format != "cyclonedx-xml" {
+		t.Errorf("unexpected format: %s", doc.Format)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Name != "comp-a" || doc.Components[0].Licenses[0] != "MIT" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+}
+
+func TestDecodeCycloneDXXMLWrongFormat(t *testing.T) {
+	if _, err := DecodeCycloneDXXML([]byte(`<notabom/>`)); err == nil {
+		t.Fatal("expected an error for a non-CycloneDX root element")
+	}
+}
+
+func TestDecodeSPDXTagValue(t *testing.T) {
+	data := []byte(`SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+Created: 2024-01-01T00:00:00Z
+Creator: Tool: syft
+
+PackageName: comp-a
+SPDXID: SPDXRef-comp-a
+PackageVersion: 1.0.0
+PackageLicenseConcluded: LicenseRef-custom
+PackageLicenseDeclared: NOASSERTION
+ExternalRef: PACKAGE-MANAGER purl pkg:npm/comp-a@1.0.0
+PackageChecksum: SHA256: deadbeef
+
+Relationship: SPDXRef-DOCUMENT DEPENDS_ON SPDXRef-comp-a
+
+LicenseID: LicenseRef-custom
+LicenseName: Acme Proprietary License
+`)
+
+	doc, err := DecodeSPDXTagValue(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Format != "spdx-tagvalue" || doc.SpecVersion != "SPDX-2.3" {
+		t.Errorf("unexpected format/version: %s %s", doc.Format, doc.SpecVersion)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Name != "comp-a" || doc.Components[0].PURL != "pkg:npm/comp-a@1.0.0" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+	if doc.Components[0].Licenses[0] != "LicenseRef-custom" {
+		t.Fatalf("unexpected license: %+v", doc.Components[0].Licenses)
+	}
+	if doc.LicenseRefs["LicenseRef-custom"] != "Acme Proprietary License" {
+		t.Fatalf("unexpected license refs: %+v", doc.LicenseRefs)
+	}
+	if len(doc.Dependencies) != 1 || doc.Dependencies[0].Ref != "SPDXRef-DOCUMENT" {
+		t.Fatalf("unexpected dependencies: %+v", doc.Dependencies)
+	}
+}
+
+func TestDecodeSPDXTagValueMissingVersion(t *testing.T) {
+	if _, err := DecodeSPDXTagValue([]byte(`PackageName: comp-a`)); err == nil {
+		t.Fatal("expected an error for a document missing SPDXVersion")
+	}
+}
+
+func TestDecodeSyftJSON(t *testing.T) {
+	data := []byte(`{
+		"descriptor": {"name": "syft", "version": "1.0.0"},
+		"schema": {"version": "16.0.1"},
+		"artifacts": [
+			{"id": "comp-a", "name": "comp-a", "version": "1.0.0", "purl": "pkg:npm/comp-a@1.0.0", "cpes": ["cpe:2.3:a:comp-a:comp-a:1.0.0:*:*:*:*:*:*:*"], "licenses": [{"value": "MIT", "spdxExpression": "MIT"}]}
+		]
+	}`)
+
+	doc, err := DecodeSyftJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Format != "syft" {
+		t.Errorf("unexpected format: %s", doc.Format)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Name != "comp-a" || doc.Components[0].Licenses[0] != "MIT" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+	if doc.Components[0].CPE != "cpe:2.3:a:comp-a:comp-a:1.0.0:*:*:*:*:*:*:*" {
+		t.Fatalf("unexpected CPE: %s", doc.Components[0].CPE)
+	}
+}
+
+func TestDecodeSyftJSONLegacyLicenseStrings(t *testing.T) {
+	data := []byte(`{"descriptor": {"name": "syft"}, "artifacts": [{"name": "comp-a", "licenses": ["MIT"]}]}`)
+
+	doc, err := DecodeSyftJSON(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(doc.Components) != 1 || doc.Components[0].Licenses[0] != "MIT" {
+		t.Fatalf("unexpected components: %+v", doc.Components)
+	}
+}
+
+func TestDecodeUnsupportedFormat(t *testing.T) {
+	if _, err := Decode(nil, "spdx3"); err == nil {
+		t.Fatal("expected an error for an unimplemented format")
+	}
+	if _, err := Decode(nil, "unknown"); err == nil {
+		t.Fatal("expected an error for an unsupported format")
+	}
+}
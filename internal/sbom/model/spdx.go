@@ -0,0 +1,235 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// spdxDocument mirrors the subset of the SPDX 2.3 JSON schema needed to
+// populate a Document; unrecognized fields are ignored. SPDX 3.0's
+// restructured JSON-LD shape is not covered here (see Decode).
+type spdxDocument struct {
+	SPDXVersion  string `json:"spdxVersion"`
+	SPDXID       string `json:"SPDXID"`
+	CreationInfo struct {
+		Created string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages      []spdxPackage `json:"packages"`
+	Relationships []struct {
+		SPDXElementID      string `json:"spdxElementId"`
+		RelationshipType   string `json:"relationshipType"`
+		RelatedSPDXElement string `json:"relatedSpdxElement"`
+	} `json:"relationships"`
+	HasExtractedLicensingInfos []struct {
+		LicenseID string `json:"licenseId"`
+		Name      string `json:"name"`
+	} `json:"hasExtractedLicensingInfos"`
+}
+
+type spdxPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	Supplier         string `json:"supplier"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceCategory string `json:"referenceCategory"`
+		ReferenceType     string `json:"referenceType"`
+		ReferenceLocator  string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+	Checksums []struct {
+		Algorithm     string `json:"algorithm"`
+		ChecksumValue string `json:"checksumValue"`
+	} `json:"checksums"`
+}
+
+// spdxOutDocument mirrors spdxDocument but only carries the fields
+// EncodeSPDXJSON populates; its JSON layout matches what DecodeSPDXJSON
+// accepts.
+type spdxOutDocument struct {
+	SPDXVersion  string `json:"spdxVersion"`
+	SPDXID       string `json:"SPDXID"`
+	Name         string `json:"name"`
+	CreationInfo struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators,omitempty"`
+	} `json:"creationInfo"`
+	Packages      []spdxOutPackage      `json:"packages,omitempty"`
+	Relationships []spdxOutRelationship `json:"relationships,omitempty"`
+}
+
+type spdxOutPackage struct {
+	SPDXID           string `json:"SPDXID"`
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo,omitempty"`
+	Supplier         string `json:"supplier,omitempty"`
+	LicenseConcluded string `json:"licenseConcluded,omitempty"`
+	ExternalRefs     []spdxOutExternalRef `json:"externalRefs,omitempty"`
+	Checksums        []spdxOutChecksum    `json:"checksums,omitempty"`
+}
+
+type spdxOutExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxOutChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxOutRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// EncodeSPDXJSON serializes doc as an SPDX 2.3 JSON document, using
+// doc.SpecVersion as the declared spdxVersion (callers that build merged
+// documents should set this to "SPDX-2.3"). Each component becomes a
+// package whose licenseConcluded is its first license; vulnerabilities,
+// Document.LicenseRefs (extracted licensing info), and Metadata.Tool are
+// not emitted.
+func EncodeSPDXJSON(doc *Document) ([]byte, error) {
+	out := spdxOutDocument{
+		SPDXVersion: doc.SpecVersion,
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        "clickbom-converted-sbom",
+	}
+	out.CreationInfo.Created = doc.Metadata.Timestamp
+	if doc.Metadata.Author != "" {
+		out.CreationInfo.Creators = append(out.CreationInfo.Creators, doc.Metadata.Author)
+	}
+
+	for _, c := range doc.Components {
+		pkg := spdxOutPackage{
+			SPDXID:      c.ID,
+			Name:        c.Name,
+			VersionInfo: c.Version,
+			Supplier:    c.Supplier,
+		}
+		if pkg.SPDXID == "" {
+			pkg.SPDXID = fmt.Sprintf("SPDXRef-Package-%s", c.Name)
+		}
+		if len(c.Licenses) > 0 {
+			pkg.LicenseConcluded = c.Licenses[0]
+		}
+		if c.PURL != "" {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, spdxOutExternalRef{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  c.PURL,
+			})
+		}
+		if c.CPE != "" {
+			pkg.ExternalRefs = append(pkg.ExternalRefs, spdxOutExternalRef{
+				ReferenceCategory: "SECURITY",
+				ReferenceType:     "cpe23Type",
+				ReferenceLocator:  c.CPE,
+			})
+		}
+		for algo, value := range c.Hashes {
+			pkg.Checksums = append(pkg.Checksums, spdxOutChecksum{Algorithm: algo, ChecksumValue: value})
+		}
+		sort.Slice(pkg.Checksums, func(i, j int) bool { return pkg.Checksums[i].Algorithm < pkg.Checksums[j].Algorithm })
+		out.Packages = append(out.Packages, pkg)
+	}
+
+	for _, d := range doc.Dependencies {
+		for _, dep := range d.DependsOn {
+			out.Relationships = append(out.Relationships, spdxOutRelationship{
+				SPDXElementID:      d.Ref,
+				RelationshipType:   "DEPENDS_ON",
+				RelatedSPDXElement: dep,
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("model: failed to marshal SPDX document: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeSPDXJSON decodes an SPDX 2.3 JSON document into a Document.
+// "DEPENDS_ON" and "DEPENDENCY_OF" relationships are folded into
+// Document.Dependencies; everything else is ignored, consistent with how
+// DependsOn edges are modeled for CycloneDX.
+func DecodeSPDXJSON(data []byte) (*Document, error) {
+	var spdx spdxDocument
+	if err := json.Unmarshal(data, &spdx); err != nil {
+		return nil, fmt.Errorf("model: failed to parse SPDX JSON: %w", err)
+	}
+	if spdx.SPDXVersion == "" {
+		return nil, fmt.Errorf("model: not an SPDX document (missing spdxVersion)")
+	}
+
+	doc := &Document{
+		Format:      "spdxjson",
+		SpecVersion: spdx.SPDXVersion,
+	}
+
+	doc.Metadata.Timestamp = spdx.CreationInfo.Created
+	if len(spdx.CreationInfo.Creators) > 0 {
+		doc.Metadata.Author = spdx.CreationInfo.Creators[0]
+	}
+
+	for _, p := range spdx.Packages {
+		comp := Component{
+			ID:      p.SPDXID,
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			Supplier: p.Supplier,
+		}
+		if license := p.LicenseConcluded; license != "" && license != "NOASSERTION" {
+			comp.Licenses = append(comp.Licenses, license)
+		} else if license := p.LicenseDeclared; license != "" && license != "NOASSERTION" {
+			comp.Licenses = append(comp.Licenses, license)
+		}
+		for _, ref := range p.ExternalRefs {
+			switch ref.ReferenceType {
+			case "purl":
+				comp.PURL = ref.ReferenceLocator
+			case "cpe23Type", "cpe22Type":
+				comp.CPE = ref.ReferenceLocator
+			}
+		}
+		if len(p.Checksums) > 0 {
+			comp.Hashes = make(map[string]string, len(p.Checksums))
+			for _, c := range p.Checksums {
+				comp.Hashes[c.Algorithm] = c.ChecksumValue
+			}
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	dependsOn := map[string][]string{}
+	for _, rel := range spdx.Relationships {
+		switch rel.RelationshipType {
+		case "DEPENDS_ON":
+			dependsOn[rel.SPDXElementID] = append(dependsOn[rel.SPDXElementID], rel.RelatedSPDXElement)
+		case "DEPENDENCY_OF":
+			dependsOn[rel.RelatedSPDXElement] = append(dependsOn[rel.RelatedSPDXElement], rel.SPDXElementID)
+		}
+	}
+	for ref, deps := range dependsOn {
+		doc.Dependencies = append(doc.Dependencies, Dependency{Ref: ref, DependsOn: deps})
+	}
+
+	for _, info := range spdx.HasExtractedLicensingInfos {
+		if info.LicenseID == "" {
+			continue
+		}
+		if doc.LicenseRefs == nil {
+			doc.LicenseRefs = make(map[string]string, len(spdx.HasExtractedLicensingInfos))
+		}
+		doc.LicenseRefs[info.LicenseID] = info.Name
+	}
+
+	return doc, nil
+}
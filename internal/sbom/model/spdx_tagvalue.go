@@ -0,0 +1,143 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DecodeSPDXTagValue decodes an SPDX 2.3 tag-value document (the classic
+// "Tag: Value" format predating SPDX's JSON schema) into a Document, using
+// the same field mapping as DecodeSPDXJSON. Packages are delimited by
+// "PackageName:" tags; "LicenseID:"/"LicenseName:" pairs outside of any
+// package populate Document.LicenseRefs.
+func DecodeSPDXTagValue(data []byte) (*Document, error) {
+	doc := &Document{Format: "spdx-tagvalue"}
+
+	var curPackage *Component
+	var curConcluded, curDeclared string
+	var curLicenseRef string
+	dependsOn := map[string][]string{}
+
+	flushPackage := func() {
+		if curPackage == nil {
+			return
+		}
+		if curConcluded != "" && curConcluded != "NOASSERTION" {
+			curPackage.Licenses = append(curPackage.Licenses, curConcluded)
+		} else if curDeclared != "" && curDeclared != "NOASSERTION" {
+			curPackage.Licenses = append(curPackage.Licenses, curDeclared)
+		}
+		doc.Components = append(doc.Components, *curPackage)
+		curPackage = nil
+		curConcluded, curDeclared = "", ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tag, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		tag = strings.TrimSpace(tag)
+		value = strings.TrimSpace(value)
+
+		switch tag {
+		case "SPDXVersion":
+			doc.SpecVersion = value
+		case "Created":
+			doc.Metadata.Timestamp = value
+		case "Creator":
+			if doc.Metadata.Author == "" {
+				doc.Metadata.Author = value
+			}
+
+		case "PackageName":
+			flushPackage()
+			curPackage = &Component{Name: value}
+			curLicenseRef = ""
+
+		case "SPDXID":
+			if curPackage != nil {
+				curPackage.ID = value
+			}
+		case "PackageVersion":
+			if curPackage != nil {
+				curPackage.Version = value
+			}
+		case "PackageSupplier":
+			if curPackage != nil {
+				curPackage.Supplier = value
+			}
+		case "PackageLicenseConcluded":
+			curConcluded = value
+		case "PackageLicenseDeclared":
+			curDeclared = value
+		case "PackageChecksum":
+			if curPackage != nil {
+				algo, sum, ok := strings.Cut(value, ":")
+				if ok {
+					if curPackage.Hashes == nil {
+						curPackage.Hashes = map[string]string{}
+					}
+					curPackage.Hashes[strings.TrimSpace(algo)] = strings.TrimSpace(sum)
+				}
+			}
+		case "ExternalRef":
+			if curPackage != nil {
+				fields := strings.Fields(value)
+				if len(fields) == 3 {
+					switch fields[1] {
+					case "purl":
+						curPackage.PURL = fields[2]
+					case "cpe23Type", "cpe22Type":
+						curPackage.CPE = fields[2]
+					}
+				}
+			}
+
+		case "Relationship":
+			fields := strings.Fields(value)
+			if len(fields) == 3 {
+				left, relType, right := fields[0], fields[1], fields[2]
+				switch relType {
+				case "DEPENDS_ON":
+					dependsOn[left] = append(dependsOn[left], right)
+				case "DEPENDENCY_OF":
+					dependsOn[right] = append(dependsOn[right], left)
+				}
+			}
+
+		case "LicenseID":
+			curLicenseRef = value
+		case "LicenseName":
+			if curLicenseRef != "" {
+				if doc.LicenseRefs == nil {
+					doc.LicenseRefs = map[string]string{}
+				}
+				doc.LicenseRefs[curLicenseRef] = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("model: failed to read SPDX tag-value document: %w", err)
+	}
+	flushPackage()
+
+	if doc.SpecVersion == "" {
+		return nil, fmt.Errorf("model: not an SPDX tag-value document (missing SPDXVersion)")
+	}
+
+	for ref, deps := range dependsOn {
+		doc.Dependencies = append(doc.Dependencies, Dependency{Ref: ref, DependsOn: deps})
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// syftDocument mirrors the subset of Syft's native JSON output
+// (github.com/anchore/syft) needed to populate a Document. Syft is not an
+// SBOM standard itself, so fields map loosely onto Component: "artifacts"
+// become Components, and there is no equivalent of CycloneDX/SPDX
+// dependency or vulnerability sections.
+type syftDocument struct {
+	Descriptor struct {
+		Name string `json:"name"`
+	} `json:"descriptor"`
+	Schema struct {
+		Version string `json:"version"`
+	} `json:"schema"`
+	Artifacts []syftArtifact `json:"artifacts"`
+}
+
+type syftArtifact struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	PURL     string          `json:"purl"`
+	CPEs     []string        `json:"cpes"`
+	Licenses []syftLicense   `json:"licenses"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// syftLicense accepts both Syft's older "licenses": ["MIT"] shape and its
+// newer structured {"value": "MIT", "spdxExpression": "MIT"} shape.
+type syftLicense struct {
+	Value          string
+	SPDXExpression string
+}
+
+func (l *syftLicense) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		l.Value = s
+		return nil
+	}
+
+	var structured struct {
+		Value          string `json:"value"`
+		SPDXExpression string `json:"spdxExpression"`
+	}
+	if err := json.Unmarshal(data, &structured); err != nil {
+		return err
+	}
+	l.Value = structured.Value
+	l.SPDXExpression = structured.SPDXExpression
+	return nil
+}
+
+func (l syftLicense) id() string {
+	if l.SPDXExpression != "" {
+		return l.SPDXExpression
+	}
+	return l.Value
+}
+
+// DecodeSyftJSON decodes a Syft native JSON document into a Document.
+// Syft artifacts carry no supplier or checksum fields directly comparable
+// to CycloneDX/SPDX, so Component.Supplier and Component.Hashes are left
+// empty.
+func DecodeSyftJSON(data []byte) (*Document, error) {
+	var syft syftDocument
+	if err := json.Unmarshal(data, &syft); err != nil {
+		return nil, fmt.Errorf("model: failed to parse Syft JSON: %w", err)
+	}
+	if len(syft.Artifacts) == 0 && syft.Descriptor.Name != "syft" {
+		return nil, fmt.Errorf("model: not a Syft document (missing artifacts/descriptor)")
+	}
+
+	doc := &Document{
+		Format:      "syft",
+		SpecVersion: syft.Schema.Version,
+	}
+	doc.Metadata.Tool = syft.Descriptor.Name
+
+	for _, a := range syft.Artifacts {
+		comp := Component{
+			ID:      a.ID,
+			Name:    a.Name,
+			Version: a.Version,
+			PURL:    a.PURL,
+		}
+		if len(a.CPEs) > 0 {
+			comp.CPE = a.CPEs[0]
+		}
+		for _, l := range a.Licenses {
+			if id := l.id(); id != "" {
+				comp.Licenses = append(comp.Licenses, id)
+			}
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	return doc, nil
+}
@@ -2,11 +2,15 @@
 package sbom
 
 import (
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
 	"github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
@@ -20,6 +24,21 @@ const (
 	FormatSPDXJSON Format = "spdxjson"
 	// FormatUnknown represents an unknown SBOM format.
 	FormatUnknown Format = "unknown"
+	// FormatInTotoAttestation represents an SBOM wrapped in an in-toto/DSSE attestation.
+	FormatInTotoAttestation Format = "intoto"
+	// FormatCycloneDXXML represents the CycloneDX XML SBOM format.
+	FormatCycloneDXXML Format = "cyclonedx-xml"
+	// FormatSPDXTagValue represents the SPDX 2.3 tag-value SBOM format.
+	FormatSPDXTagValue Format = "spdx-tagvalue"
+	// FormatSyft represents Syft's native JSON output format.
+	FormatSyft Format = "syft"
+	// FormatAttestCycloneDXJSON represents an in-toto/DSSE attestation
+	// whose predicate is a CycloneDX JSON SBOM (predicateType
+	// "https://cyclonedx.org/bom" or the legacy "https://cyclonedx.org/schema").
+	FormatAttestCycloneDXJSON Format = "attest-cyclonedx"
+	// FormatAttestSPDXJSON represents an in-toto/DSSE attestation whose
+	// predicate is an SPDX JSON SBOM (predicateType "https://spdx.dev/Document").
+	FormatAttestSPDXJSON Format = "attest-spdx"
 )
 
 // CycloneDXDocument represents the basic structure of a CycloneDX SBOM.
@@ -34,6 +53,21 @@ type SPDXDocument struct {
 	SPDXID      string `json:"SPDXID"`
 }
 
+// syftDescriptor detects Syft's native JSON output, which declares no
+// bomFormat/spdxVersion of its own.
+type syftDescriptor struct {
+	Descriptor struct {
+		Name string `json:"name"`
+	} `json:"descriptor"`
+	Artifacts []json.RawMessage `json:"artifacts"`
+}
+
+// cyclonedxXMLRoot detects a CycloneDX XML document by its root element,
+// without decoding the rest of the document.
+type cyclonedxXMLRoot struct {
+	XMLName xml.Name `xml:"bom"`
+}
+
 // DetectSBOMFormat detects the format of the SBOM file based on its content.
 func DetectSBOMFormat(filename string) (Format, error) {
 	logger.Debug("Detecting SBOM format for: %s", filename)
@@ -43,6 +77,36 @@ func DetectSBOMFormat(filename string) (Format, error) {
 		return FormatUnknown, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	// Fast path: in-toto attestation envelope. Peek predicateType to report
+	// the wrapped SBOM's own format when recognized, falling back to the
+	// generic FormatInTotoAttestation otherwise; either way the caller
+	// must still unwrap with ExtractSBOMFromWrapper before parsing it.
+	if isInTotoAttestation(data) {
+		format := attestationPredicateFormat(data)
+		logger.Debug("Detected format: %s", format)
+		return format, nil
+	}
+
+	trimmed := bytes.TrimSpace(data)
+
+	// SPDX tag-value is line-oriented, not JSON/XML; the document must
+	// start with its mandatory SPDXVersion tag.
+	if bytes.HasPrefix(trimmed, []byte("SPDXVersion:")) {
+		logger.Debug("Detected format: SPDX tag-value")
+		return FormatSPDXTagValue, nil
+	}
+
+	// CycloneDX XML, detected by root element rather than a full parse.
+	if bytes.HasPrefix(trimmed, []byte("<?xml")) || bytes.HasPrefix(trimmed, []byte("<bom")) {
+		var xmlRoot cyclonedxXMLRoot
+		if err := xml.Unmarshal(data, &xmlRoot); err == nil && xmlRoot.XMLName.Local == "bom" {
+			logger.Debug("Detected format: CycloneDX XML")
+			return FormatCycloneDXXML, nil
+		}
+		logger.Warning("Unknown SBOM format")
+		return FormatUnknown, nil
+	}
+
 	// Try CycloneDX
 	var cdx CycloneDXDocument
 	if err := json.Unmarshal(data, &cdx); err == nil {
@@ -61,6 +125,15 @@ func DetectSBOMFormat(filename string) (Format, error) {
 		}
 	}
 
+	// Try Syft, which declares neither bomFormat nor spdxVersion.
+	var syft syftDescriptor
+	if err := json.Unmarshal(data, &syft); err == nil {
+		if strings.EqualFold(syft.Descriptor.Name, "syft") || len(syft.Artifacts) > 0 {
+			logger.Debug("Detected format: Syft")
+			return FormatSyft, nil
+		}
+	}
+
 	logger.Warning("Unknown SBOM format")
 	return FormatUnknown, nil
 }
@@ -74,6 +147,12 @@ func ExtractSBOMFromWrapper(inputFile, outputFile string) error {
 		return fmt.Errorf("failed to read input file: %w", err)
 	}
 
+	// Check for an in-toto/DSSE attestation envelope (single JSON object or JSONL)
+	// before falling back to the GitHub wrapper format.
+	if isInTotoAttestation(data) {
+		return extractFromInTotoAttestation(data, outputFile)
+	}
+
 	var wrapper map[string]interface{}
 	if err := json.Unmarshal(data, &wrapper); err != nil {
 		return fmt.Errorf("failed to parse JSON: %w", err)
@@ -105,8 +184,61 @@ func ExtractSBOMFromWrapper(inputFile, outputFile string) error {
 	return nil
 }
 
+// ParseDocument detects the format of the SBOM at filename and decodes it
+// into a provider-agnostic model.Document. Wrapped formats (in-toto
+// attestations, the GitHub wrapper) must be unwrapped with
+// ExtractSBOMFromWrapper first.
+func ParseDocument(filename string) (*model.Document, error) {
+	format, err := DetectSBOMFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case FormatUnknown, FormatInTotoAttestation, FormatAttestCycloneDXJSON, FormatAttestSPDXJSON:
+		return nil, fmt.Errorf("cannot parse SBOM of format %q into a document; unwrap it with ExtractSBOMFromWrapper first", format)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	doc, err := model.Decode(data, string(format))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM: %w", err)
+	}
+
+	return doc, nil
+}
+
+// Converter selects how ConvertSBOM performs a format conversion.
+type Converter string
+
+const (
+	// ConverterCLI shells out to the cyclonedx-cli binary. This is the
+	// default for backward compatibility with existing pipelines.
+	ConverterCLI Converter = "cli"
+	// ConverterNative converts in-process via model.Decode/model.Encode*,
+	// requiring no external binary.
+	ConverterNative Converter = "native"
+)
+
+// ConvertOption configures a ConvertSBOM call.
+type ConvertOption func(*convertOptions)
+
+type convertOptions struct {
+	converter Converter
+}
+
+// WithConverter selects the Converter ConvertSBOM uses. Left unset,
+// ConvertSBOM defaults to ConverterCLI but falls back to ConverterNative
+// automatically when the cyclonedx binary is not on PATH.
+func WithConverter(c Converter) ConvertOption {
+	return func(o *convertOptions) { o.converter = c }
+}
+
 // ConvertSBOM converts the SBOM from one format to another.
-func ConvertSBOM(inputFile, outputFile string, sourceFormat, targetFormat Format) error {
+func ConvertSBOM(inputFile, outputFile string, sourceFormat, targetFormat Format, opts ...ConvertOption) error {
 	if sourceFormat == targetFormat {
 		logger.Info("Source and target formats are the same, copying file")
 		data, err := os.ReadFile(inputFile)
@@ -116,9 +248,26 @@ func ConvertSBOM(inputFile, outputFile string, sourceFormat, targetFormat Format
 		return os.WriteFile(outputFile, data, 0644)
 	}
 
-	logger.Info("Converting SBOM from %s to %s", sourceFormat, targetFormat)
+	var options convertOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	converter := options.converter
+	if converter == "" {
+		converter = ConverterCLI
+		if _, err := exec.LookPath("cyclonedx"); err != nil {
+			logger.Info("cyclonedx binary not found, falling back to native conversion")
+			converter = ConverterNative
+		}
+	}
+
+	logger.Info("Converting SBOM from %s to %s (converter=%s)", sourceFormat, targetFormat, converter)
+
+	if converter == ConverterNative {
+		return convertSBOMNative(inputFile, outputFile, sourceFormat, targetFormat)
+	}
 
-	// Use cyclonedx-cli for conversion
 	cmd := exec.Command("cyclonedx",
 		"convert",
 		"--input-file", inputFile,
@@ -135,3 +284,51 @@ func ConvertSBOM(inputFile, outputFile string, sourceFormat, targetFormat Format
 	logger.Success("SBOM converted successfully")
 	return nil
 }
+
+// convertSBOMNative converts inputFile to outputFile by decoding it into
+// the normalized model.Document and re-encoding it as targetFormat,
+// without shelling out to cyclonedx-cli. Only the cyclonedx and spdxjson
+// targets are supported natively; other targets still require
+// ConverterCLI. Fields the model does not carry (e.g. CycloneDX
+// vulnerability ratings' extended metadata, SPDX creator tool/organization
+// distinctions), and fields the target encoder does not emit (see
+// model.EncodeCycloneDXJSON and model.EncodeSPDXJSON), are dropped; the
+// only warning logged is for vulnerability records dropped on conversion
+// to SPDX.
+func convertSBOMNative(inputFile, outputFile string, sourceFormat, targetFormat Format) error {
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	doc, err := model.Decode(data, string(sourceFormat))
+	if err != nil {
+		return fmt.Errorf("failed to decode %s SBOM: %w", sourceFormat, err)
+	}
+
+	var out []byte
+	switch targetFormat {
+	case FormatCycloneDX:
+		doc.SpecVersion = "1.5"
+		out, err = model.EncodeCycloneDXJSON(doc)
+	case FormatSPDXJSON:
+		doc.SpecVersion = "SPDX-2.3"
+		out, err = model.EncodeSPDXJSON(doc)
+	default:
+		return fmt.Errorf("native conversion to %q is not supported; use ConverterCLI", targetFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode %s SBOM: %w", targetFormat, err)
+	}
+
+	if len(doc.Vulnerabilities) > 0 && targetFormat == FormatSPDXJSON {
+		logger.Warning("Native conversion to SPDX drops %d vulnerability record(s), which SPDX 2.3 has no field for", len(doc.Vulnerabilities))
+	}
+
+	if err := os.WriteFile(outputFile, out, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	logger.Success("SBOM converted successfully (native)")
+	return nil
+}
@@ -0,0 +1,58 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+)
+
+// Source is implemented by every SBOM provider that can be fetched and
+// registered with the package-level registry. Providers outside this
+// package can implement Source and call Register in an init func without
+// this package needing to know about them.
+type Source interface {
+	// Name returns the provider's registry name (e.g. "github", "mend").
+	Name() string
+	// Validate reports whether the source has everything it needs to
+	// fetch an SBOM, returning an error describing what's missing.
+	Validate() error
+	// Fetch downloads the SBOM and writes it to outputFile.
+	Fetch(ctx context.Context, outputFile string) error
+}
+
+// Constructor builds a Source from the process configuration.
+type Constructor func(cfg *config.Config) (Source, error)
+
+var registry = map[string]Constructor{}
+
+// Register adds a named provider constructor to the registry. It panics
+// if name is already registered, mirroring database/sql driver
+// registration; Register is expected to be called from package init.
+func Register(name string, constructor Constructor) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("sbom: source %q already registered", name))
+	}
+	registry[name] = constructor
+}
+
+// NewFromConfig returns the Source configured by cfg.SBOMSource.
+func NewFromConfig(cfg *config.Config) (Source, error) {
+	constructor, ok := registry[cfg.SBOMSource]
+	if !ok {
+		return nil, fmt.Errorf("unsupported SBOM source: %s", cfg.SBOMSource)
+	}
+	return constructor(cfg)
+}
+
+// RegisteredSources returns the names of all registered providers, sorted
+// alphabetically.
+func RegisteredSources() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
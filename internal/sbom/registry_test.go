@@ -0,0 +1,51 @@
+package sbom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+)
+
+type fakeSource struct{ name string }
+
+func (f *fakeSource) Name() string    { return f.name }
+func (f *fakeSource) Validate() error { return nil }
+func (f *fakeSource) Fetch(ctx context.Context, outputFile string) error { return nil }
+
+func TestRegisterAndNewFromConfig(t *testing.T) {
+	Register("fake-source-for-test", func(cfg *config.Config) (Source, error) {
+		return &fakeSource{name: "fake-source-for-test"}, nil
+	})
+
+	cfg := &config.Config{SBOMSource: "fake-source-for-test"}
+	source, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if source.Name() != "fake-source-for-test" {
+		t.Errorf("expected fake-source-for-test, got %s", source.Name())
+	}
+}
+
+func TestNewFromConfigUnknownSource(t *testing.T) {
+	cfg := &config.Config{SBOMSource: "does-not-exist"}
+	if _, err := NewFromConfig(cfg); err == nil {
+		t.Fatal("expected an error for an unregistered source")
+	}
+}
+
+func TestRegisteredSourcesIncludesBuiltins(t *testing.T) {
+	names := RegisteredSources()
+	want := map[string]bool{"github": false, "mend": false, "wiz": false, "dtrack": false}
+	for _, name := range names {
+		if _, ok := want[name]; ok {
+			want[name] = true
+		}
+	}
+	for name, found := range want {
+		if !found {
+			t.Errorf("expected %q to be registered", name)
+		}
+	}
+}
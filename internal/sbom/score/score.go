@@ -0,0 +1,262 @@
+// Package score computes sbomqs-style quality scores for processed SBOM
+// documents so low-quality SBOMs can be flagged or rejected before ingestion.
+package score
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// Category identifies one dimension of SBOM quality.
+type Category string
+
+const (
+	// CategoryNTIA scores coverage of the NTIA minimum elements.
+	CategoryNTIA Category = "ntia"
+	// CategoryStructural scores structural completeness of the document.
+	CategoryStructural Category = "structural"
+	// CategorySemantic scores presence of component identifiers and licenses.
+	CategorySemantic Category = "semantic"
+	// CategorySharing scores how ready the document is for sharing downstream.
+	CategorySharing Category = "sharing"
+)
+
+// weights mirrors sbomqs' default category weighting; NTIA coverage counts
+// for the most because it is the baseline most consumers check for first.
+var weights = map[Category]float64{
+	CategoryNTIA:       0.4,
+	CategoryStructural: 0.2,
+	CategorySemantic:   0.25,
+	CategorySharing:    0.15,
+}
+
+// Report is the JSON document written alongside the SBOM with its score breakdown.
+type Report struct {
+	Overall    float64               `json:"overall"`
+	Categories map[Category]float64 `json:"categories"`
+	Components int                   `json:"components"`
+}
+
+// ScoreSBOM reads the SBOM at sbomFile and computes a quality Report for it.
+// sbomFormat is either "cyclonedx" or "spdxjson".
+func ScoreSBOM(sbomFile, sbomFormat string) (*Report, error) {
+	logger.Info("Scoring SBOM quality for %s", sbomFile)
+
+	data, err := os.ReadFile(sbomFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	components, err := extractComponents(data, sbomFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract components: %w", err)
+	}
+
+	report := &Report{
+		Categories: map[Category]float64{
+			CategoryNTIA:       scoreNTIA(components),
+			CategoryStructural: scoreStructural(data, sbomFormat),
+			CategorySemantic:   scoreSemantic(components),
+			CategorySharing:    scoreSharing(components, sbomFormat),
+		},
+		Components: len(components),
+	}
+
+	var overall float64
+	for category, weight := range weights {
+		overall += report.Categories[category] * weight
+	}
+	report.Overall = overall
+
+	logger.Info("SBOM quality score: %.2f/10 (%d components)", report.Overall, len(components))
+	return report, nil
+}
+
+// WriteReport writes the report as JSON to outputFile.
+func WriteReport(report *Report, outputFile string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal score report: %w", err)
+	}
+	if err := os.WriteFile(outputFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write score report: %w", err)
+	}
+	return nil
+}
+
+func extractComponents(data []byte, sbomFormat string) ([]map[string]interface{}, error) {
+	switch sbomFormat {
+	case "cyclonedx":
+		var cdx struct {
+			Components []map[string]interface{} `json:"components"`
+		}
+		if err := json.Unmarshal(data, &cdx); err != nil {
+			return nil, err
+		}
+		return cdx.Components, nil
+
+	case "spdxjson":
+		var spdx struct {
+			Packages []map[string]interface{} `json:"packages"`
+		}
+		if err := json.Unmarshal(data, &spdx); err != nil {
+			return nil, err
+		}
+		return spdx.Packages, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format: %s", sbomFormat)
+	}
+}
+
+// scoreNTIA scores per-component coverage of the NTIA minimum elements:
+// component name, version, supplier (or author/originator), and a unique
+// identifier. The document's timestamp is not checked.
+func scoreNTIA(components []map[string]interface{}) float64 {
+	if len(components) == 0 {
+		return 0
+	}
+
+	elements := []func(map[string]interface{}) bool{
+		hasField("name"),
+		hasAnyField("version", "versionInfo"),
+		hasAnyField("supplier", "author", "originator"),
+		hasAnyPURLOrID,
+	}
+
+	var total float64
+	for _, comp := range components {
+		var covered int
+		for _, check := range elements {
+			if check(comp) {
+				covered++
+			}
+		}
+		total += float64(covered) / float64(len(elements))
+	}
+
+	return (total / float64(len(components))) * 10
+}
+
+// scoreStructural scores whether the document declares a spec version and
+// parses into the expected top-level shape.
+func scoreStructural(data []byte, sbomFormat string) float64 {
+	var hasSpecVersion bool
+
+	switch sbomFormat {
+	case "cyclonedx":
+		var cdx struct {
+			BOMFormat   string `json:"bomFormat"`
+			SpecVersion string `json:"specVersion"`
+		}
+		if json.Unmarshal(data, &cdx) == nil {
+			hasSpecVersion = cdx.BOMFormat == "CycloneDX" && cdx.SpecVersion != ""
+		}
+	case "spdxjson":
+		var spdx struct {
+			SPDXVersion string `json:"spdxVersion"`
+			SPDXID      string `json:"SPDXID"`
+		}
+		if json.Unmarshal(data, &spdx) == nil {
+			hasSpecVersion = spdx.SPDXVersion != "" && spdx.SPDXID != ""
+		}
+	}
+
+	if hasSpecVersion {
+		return 10
+	}
+	return 0
+}
+
+// scoreSemantic scores the fraction of components with a PURL/CPE/SWID
+// identifier, a SHA digest, and a recognizable license.
+func scoreSemantic(components []map[string]interface{}) float64 {
+	if len(components) == 0 {
+		return 0
+	}
+
+	var withID, withDigest, withLicense int
+	for _, comp := range components {
+		if hasAnyPURLOrID(comp) {
+			withID++
+		}
+		if hasField("hashes")(comp) {
+			withDigest++
+		}
+		if hasLicense(comp) {
+			withLicense++
+		}
+	}
+
+	n := float64(len(components))
+	avg := (float64(withID)/n + float64(withDigest)/n + float64(withLicense)/n) / 3
+	return avg * 10
+}
+
+// scoreSharing scores how ready the document is for sharing downstream:
+// the fraction of components carrying license information (SPDX
+// licenseConcluded/licenseDeclared, or CycloneDX licenses). Component
+// relationships are not checked.
+func scoreSharing(components []map[string]interface{}, sbomFormat string) float64 {
+	if len(components) == 0 {
+		return 0
+	}
+
+	var ready int
+	for _, comp := range components {
+		switch sbomFormat {
+		case "spdxjson":
+			if hasAnyField("licenseConcluded", "licenseDeclared")(comp) {
+				ready++
+			}
+		default:
+			if hasLicense(comp) {
+				ready++
+			}
+		}
+	}
+
+	return (float64(ready) / float64(len(components))) * 10
+}
+
+func hasField(key string) func(map[string]interface{}) bool {
+	return func(comp map[string]interface{}) bool {
+		val, ok := comp[key]
+		if !ok {
+			return false
+		}
+		switch v := val.(type) {
+		case string:
+			return v != ""
+		case []interface{}:
+			return len(v) > 0
+		default:
+			return val != nil
+		}
+	}
+}
+
+func hasAnyField(keys ...string) func(map[string]interface{}) bool {
+	return func(comp map[string]interface{}) bool {
+		for _, key := range keys {
+			if hasField(key)(comp) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func hasAnyPURLOrID(comp map[string]interface{}) bool {
+	return hasAnyField("purl", "cpe", "SPDXID", "swid")(comp)
+}
+
+func hasLicense(comp map[string]interface{}) bool {
+	if licenses, ok := comp["licenses"].([]interface{}); ok && len(licenses) > 0 {
+		return true
+	}
+	return hasAnyField("licenseConcluded", "licenseDeclared")(comp)
+}
@@ -0,0 +1,63 @@
+package score
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScoreSBOM(t *testing.T) {
+	tempDir := t.TempDir()
+	sbomFile := filepath.Join(tempDir, "sbom.json")
+
+	content := `{
+        "bomFormat": "CycloneDX",
+        "specVersion": "1.6",
+        "components": [
+            {
+                "name": "example",
+                "version": "1.0.0",
+                "purl": "pkg:npm/example@1.0.0",
+                "hashes": [{"alg": "SHA-256", "content": "abc"}],
+                "licenses": [{"license": {"id": "MIT"}}]
+            }
+        ]
+    }`
+
+	if err := os.WriteFile(sbomFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write SBOM: %v", err)
+	}
+
+	report, err := ScoreSBOM(sbomFile, "cyclonedx")
+	if err != nil {
+		t.Fatalf("ScoreSBOM failed: %v", err)
+	}
+
+	if report.Components != 1 {
+		t.Errorf("expected 1 component, got %d", report.Components)
+	}
+	if report.Overall <= 0 {
+		t.Errorf("expected a positive overall score, got %f", report.Overall)
+	}
+	if report.Categories[CategoryStructural] != 10 {
+		t.Errorf("expected full structural score, got %f", report.Categories[CategoryStructural])
+	}
+}
+
+func TestScoreSBOMEmptyComponents(t *testing.T) {
+	tempDir := t.TempDir()
+	sbomFile := filepath.Join(tempDir, "empty.json")
+
+	if err := os.WriteFile(sbomFile, []byte(`{"bomFormat":"CycloneDX","specVersion":"1.6","components":[]}`), 0644); err != nil {
+		t.Fatalf("failed to write SBOM: %v", err)
+	}
+
+	report, err := ScoreSBOM(sbomFile, "cyclonedx")
+	if err != nil {
+		t.Fatalf("ScoreSBOM failed: %v", err)
+	}
+
+	if report.Overall != report.Categories[CategoryStructural]*weights[CategoryStructural] {
+		t.Errorf("expected overall score to come only from the structural category with no components")
+	}
+}
@@ -0,0 +1,75 @@
+package sink
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/storage"
+)
+
+// BuildSinks parses cfg.Sinks (a comma-separated list of "file", "stdout",
+// an object-store backend name, "oci", and/or "clickhouse") and returns
+// the corresponding Sinks. clickhouseTable names the table the
+// "clickhouse" sink inserts into, since table naming depends on the
+// configured SBOM source (see cmd/clickbom's generateTableName).
+func BuildSinks(cfg *config.Config, objectStore storage.ObjectStore, clickhouseTable string) ([]Sink, error) {
+	var sinks []Sink
+
+	for _, name := range splitCSV(cfg.Sinks) {
+		switch name {
+		case "file":
+			if cfg.SinkFilePath == "" {
+				return nil, fmt.Errorf("sink: SINK_FILE_PATH is required for the file sink")
+			}
+			sinks = append(sinks, NewFileSink(cfg.SinkFilePath))
+
+		case "stdout":
+			sinks = append(sinks, NewStdoutSink())
+
+		case "s3", "gcs", "azblob", "object-store":
+			if objectStore == nil {
+				return nil, fmt.Errorf("sink: no object storage backend configured")
+			}
+			sinks = append(sinks, NewObjectStoreSink(objectStore, cfg.S3Bucket, cfg.S3Key, cfg.SBOMFormat))
+
+		case "clickhouse":
+			chClient, err := storage.NewClickHouseClient(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("sink: failed to create ClickHouse client: %w", err)
+			}
+			meta := storage.ScanMetadata{
+				Source:     cfg.SBOMSource,
+				Repository: cfg.Repository,
+				CommitSHA:  os.Getenv("GITHUB_SHA"),
+			}
+			sinks = append(sinks, NewClickHouseSink(chClient, clickhouseTable, cfg.SBOMFormat, meta))
+
+		case "oci":
+			if cfg.OCIRegistry == "" || cfg.OCIRepository == "" {
+				return nil, fmt.Errorf("sink: OCI_REGISTRY and OCI_REPOSITORY are required for the oci sink")
+			}
+			tag := cfg.OCITag
+			if tag == "" {
+				tag = "latest"
+			}
+			sinks = append(sinks, NewOCISink(cfg.OCIRegistry, cfg.OCIRepository, tag, cfg.OCIUsername, cfg.OCIPassword, cfg.SBOMFormat))
+
+		default:
+			return nil, fmt.Errorf("sink: unsupported sink %q", name)
+		}
+	}
+
+	return sinks, nil
+}
+
+func splitCSV(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/internal/storage"
+)
+
+// ClickHouseSink streams the SBOM's components directly into a
+// ClickHouse table, eliminating the separate ETL step of downloading the
+// archived SBOM back out of object storage.
+type ClickHouseSink struct {
+	client     *storage.ClickHouseClient
+	table      string
+	sbomFormat string
+	meta       storage.ScanMetadata
+}
+
+// NewClickHouseSink returns a Sink that inserts the SBOM's components
+// into table via client.
+func NewClickHouseSink(client *storage.ClickHouseClient, table, sbomFormat string, meta storage.ScanMetadata) *ClickHouseSink {
+	return &ClickHouseSink{client: client, table: table, sbomFormat: sbomFormat, meta: meta}
+}
+
+// Name implements Sink.
+func (c *ClickHouseSink) Name() string { return fmt.Sprintf("clickhouse:%s", c.table) }
+
+// Write implements Sink. storage.ClickHouseClient's insert methods take a
+// local file path, so raw is first buffered to a temp file.
+func (c *ClickHouseSink) Write(ctx context.Context, _ *model.Document, raw io.ReadSeeker) error {
+	tmp, err := os.CreateTemp("", "clickbom-sink-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := io.Copy(tmp, raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to buffer SBOM: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := c.client.SetupTable(ctx, c.table); err != nil {
+		return fmt.Errorf("failed to set up table: %w", err)
+	}
+
+	return c.client.InsertSBOMData(ctx, tmpPath, c.table, c.sbomFormat, c.meta)
+}
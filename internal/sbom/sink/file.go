@@ -0,0 +1,56 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+)
+
+// FileSink writes the raw SBOM to a local path.
+type FileSink struct {
+	path string
+}
+
+// NewFileSink returns a Sink that writes the raw SBOM to path.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Name implements Sink.
+func (f *FileSink) Name() string { return fmt.Sprintf("file:%s", f.path) }
+
+// Write implements Sink.
+func (f *FileSink) Write(_ context.Context, _ *model.Document, raw io.ReadSeeker) error {
+	out, err := os.Create(f.path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", f.path, err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, raw); err != nil {
+		return fmt.Errorf("failed to write %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// StdoutSink writes the raw SBOM to standard output.
+type StdoutSink struct{}
+
+// NewStdoutSink returns a Sink that writes the raw SBOM to stdout.
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{}
+}
+
+// Name implements Sink.
+func (s *StdoutSink) Name() string { return "stdout" }
+
+// Write implements Sink.
+func (s *StdoutSink) Write(_ context.Context, _ *model.Document, raw io.ReadSeeker) error {
+	if _, err := io.Copy(os.Stdout, raw); err != nil {
+		return fmt.Errorf("failed to write to stdout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/internal/storage"
+)
+
+// ObjectStoreSink archives the raw SBOM to an object storage bucket (S3,
+// GCS, or Azure Blob, via whichever storage.ObjectStore is configured).
+type ObjectStoreSink struct {
+	store      storage.ObjectStore
+	bucket     string
+	key        string
+	sbomFormat string
+}
+
+// NewObjectStoreSink returns a Sink that uploads the raw SBOM to
+// store's bucket/key.
+func NewObjectStoreSink(store storage.ObjectStore, bucket, key, sbomFormat string) *ObjectStoreSink {
+	return &ObjectStoreSink{store: store, bucket: bucket, key: key, sbomFormat: sbomFormat}
+}
+
+// Name implements Sink.
+func (o *ObjectStoreSink) Name() string {
+	return fmt.Sprintf("object-store:%s/%s", o.bucket, o.key)
+}
+
+// Write implements Sink. storage.ObjectStore.Upload takes a local file
+// path, so raw is first buffered to a temp file.
+func (o *ObjectStoreSink) Write(ctx context.Context, _ *model.Document, raw io.ReadSeeker) error {
+	tmp, err := os.CreateTemp("", "clickbom-sink-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := io.Copy(tmp, raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to buffer SBOM: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	return o.store.Upload(ctx, tmpPath, o.bucket, o.key, o.sbomFormat)
+}
@@ -0,0 +1,214 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// ociEmptyConfig is the empty JSON config blob CycloneDX/SPDX OCI
+// artifacts use in place of a real container image config, per the
+// "Publishing BOMs using OCI" convention the CycloneDX and SPDX
+// specifications both document.
+const ociEmptyConfig = "{}"
+
+// OCISink pushes the raw SBOM as an OCI artifact to a container
+// registry, using the OCI Distribution Spec HTTP API directly (monolithic
+// blob uploads + a manifest PUT), so no registry client library is
+// required.
+type OCISink struct {
+	registry   string // e.g. "ghcr.io"
+	repository string // e.g. "acme/app/sbom"
+	tag        string
+	username   string
+	password   string
+	sbomFormat string
+	httpClient *http.Client
+}
+
+// NewOCISink returns a Sink that pushes the raw SBOM to
+// registry/repository:tag. username/password are optional basic-auth
+// credentials for the registry.
+func NewOCISink(registry, repository, tag, username, password, sbomFormat string) *OCISink {
+	return &OCISink{
+		registry:   registry,
+		repository: repository,
+		tag:        tag,
+		username:   username,
+		password:   password,
+		sbomFormat: sbomFormat,
+		httpClient: &http.Client{},
+	}
+}
+
+// Name implements Sink.
+func (o *OCISink) Name() string {
+	return fmt.Sprintf("oci:%s/%s:%s", o.registry, o.repository, o.tag)
+}
+
+// mediaType returns the OCI media type used for both the config and SBOM
+// layer blobs, per the CycloneDX/SPDX OCI artifact conventions.
+func (o *OCISink) mediaType() string {
+	if o.sbomFormat == "spdxjson" {
+		return "application/spdx+json"
+	}
+	return "application/vnd.cyclonedx+json"
+}
+
+// Write implements Sink by pushing raw as an OCI artifact: an empty
+// config blob, the SBOM itself as a single layer blob, and a manifest
+// referencing both.
+func (o *OCISink) Write(ctx context.Context, _ *model.Document, raw io.ReadSeeker) error {
+	sbomBytes, err := io.ReadAll(raw)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	configDigest, err := o.pushBlob(ctx, []byte(ociEmptyConfig))
+	if err != nil {
+		return fmt.Errorf("failed to push config blob: %w", err)
+	}
+
+	layerDigest, err := o.pushBlob(ctx, sbomBytes)
+	if err != nil {
+		return fmt.Errorf("failed to push SBOM layer blob: %w", err)
+	}
+
+	manifest := ociManifest{
+		SchemaVersion: 2,
+		MediaType:     "application/vnd.oci.image.manifest.v1+json",
+		Config: ociDescriptor{
+			MediaType: o.mediaType(),
+			Digest:    configDigest,
+			Size:      int64(len(ociEmptyConfig)),
+		},
+		Layers: []ociDescriptor{
+			{
+				MediaType: o.mediaType(),
+				Digest:    layerDigest,
+				Size:      int64(len(sbomBytes)),
+				Annotations: map[string]string{
+					"org.opencontainers.image.title": "sbom.json",
+				},
+			},
+		},
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	url := fmt.Sprintf("https://%s/v2/%s/manifests/%s", o.registry, o.repository, o.tag)
+	req, err := http.NewRequestWithContext(ctx, "PUT", url, bytes.NewReader(manifestBytes))
+	if err != nil {
+		return fmt.Errorf("failed to create manifest request: %w", err)
+	}
+	req.Header.Set("Content-Type", manifest.MediaType)
+	o.setAuth(req)
+
+	resp, err := o.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("manifest push failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("manifest push failed (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	logger.Success("Pushed SBOM to %s", o.Name())
+	return nil
+}
+
+// pushBlob uploads data as a single monolithic blob and returns its
+// digest.
+func (o *OCISink) pushBlob(ctx context.Context, data []byte) (string, error) {
+	sum := sha256.Sum256(data)
+	digest := "sha256:" + hex.EncodeToString(sum[:])
+
+	startURL := fmt.Sprintf("https://%s/v2/%s/blobs/uploads/", o.registry, o.repository)
+	startReq, err := http.NewRequestWithContext(ctx, "POST", startURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload request: %w", err)
+	}
+	o.setAuth(startReq)
+
+	startResp, err := o.httpClient.Do(startReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to start blob upload: %w", err)
+	}
+	location := startResp.Header.Get("Location")
+	if err := startResp.Body.Close(); err != nil {
+		logger.Warning("Failed to close response body: %v", err)
+	}
+	if startResp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("failed to start blob upload (status %d)", startResp.StatusCode)
+	}
+	if location == "" {
+		return "", fmt.Errorf("registry did not return an upload location")
+	}
+
+	separator := "?"
+	if bytes.ContainsRune([]byte(location), '?') {
+		separator = "&"
+	}
+	uploadURL := fmt.Sprintf("%s%sdigest=%s", location, separator, digest)
+
+	putReq, err := http.NewRequestWithContext(ctx, "PUT", uploadURL, bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob request: %w", err)
+	}
+	putReq.Header.Set("Content-Type", "application/octet-stream")
+	o.setAuth(putReq)
+
+	putResp, err := o.httpClient.Do(putReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload blob: %w", err)
+	}
+	defer func() {
+		if err := putResp.Body.Close(); err != nil {
+			logger.Warning("Failed to close response body: %v", err)
+		}
+	}()
+
+	if putResp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(putResp.Body)
+		return "", fmt.Errorf("blob upload failed (status %d): %s", putResp.StatusCode, string(body))
+	}
+
+	return digest, nil
+}
+
+func (o *OCISink) setAuth(req *http.Request) {
+	if o.username != "" {
+		req.SetBasicAuth(o.username, o.password)
+	}
+}
+
+type ociManifest struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType"`
+	Config        ociDescriptor   `json:"config"`
+	Layers        []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
@@ -0,0 +1,73 @@
+// Package sink provides pluggable terminal destinations for a processed
+// SBOM. A single export run can write the raw document to several sinks
+// at once (e.g. archive the JSON to S3 while streaming parsed rows into
+// ClickHouse) via MultiSink, instead of needing a separate ETL step.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// Sink is the terminal destination for a processed SBOM. doc is the
+// parsed, provider-agnostic document; raw is the original SBOM bytes, so
+// a file/object-store/OCI sink can archive the exact document that was
+// downloaded without re-encoding it. raw is an io.ReadSeeker (rather than
+// a plain io.Reader) so MultiSink can rewind it between sinks.
+type Sink interface {
+	// Name identifies the sink for logging.
+	Name() string
+	Write(ctx context.Context, doc *model.Document, raw io.ReadSeeker) error
+}
+
+// MultiSink fans Write out to every configured sink, continuing on error
+// so one failing sink (e.g. a flaky registry) doesn't stop the others
+// from archiving the SBOM; all errors are returned joined.
+type MultiSink struct {
+	sinks []Sink
+}
+
+// NewMultiSink returns a Sink that writes to every sink in sinks.
+func NewMultiSink(sinks ...Sink) *MultiSink {
+	return &MultiSink{sinks: sinks}
+}
+
+// Name implements Sink.
+func (m *MultiSink) Name() string { return "multi" }
+
+// Write implements Sink by writing raw to every configured sink in turn.
+// raw must support re-reading from the start for each sink; callers
+// should pass an *os.File or bytes.Reader rather than a network stream.
+func (m *MultiSink) Write(ctx context.Context, doc *model.Document, raw io.ReadSeeker) error {
+	var errs []error
+	for _, s := range m.sinks {
+		if _, err := raw.Seek(0, io.SeekStart); err != nil {
+			errs = append(errs, fmt.Errorf("%s: failed to rewind SBOM: %w", s.Name(), err))
+			continue
+		}
+		logger.Info("Writing SBOM to sink: %s", s.Name())
+		if err := s.Write(ctx, doc, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name(), err))
+			continue
+		}
+		logger.Success("Wrote SBOM to sink: %s", s.Name())
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d sinks failed: %w", len(errs), len(m.sinks), joinErrors(errs))
+}
+
+func joinErrors(errs []error) error {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "; "))
+}
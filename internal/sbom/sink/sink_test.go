@@ -0,0 +1,102 @@
+package sink
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+)
+
+func TestFileSinkWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.json")
+
+	f, err := os.CreateTemp(dir, "in-*.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(`{"bomFormat":"CycloneDX"}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	s := NewFileSink(path)
+	if err := s.Write(context.Background(), nil, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != `{"bomFormat":"CycloneDX"}` {
+		t.Errorf("unexpected content: %s", got)
+	}
+}
+
+func TestMultiSinkWritesToEverySink(t *testing.T) {
+	dir := t.TempDir()
+	pathA := filepath.Join(dir, "a.json")
+	pathB := filepath.Join(dir, "b.json")
+
+	f, err := os.CreateTemp(dir, "in-*.json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer f.Close()
+	content := `{"bomFormat":"CycloneDX"}`
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	multi := NewMultiSink(NewFileSink(pathA), NewFileSink(pathB))
+	if err := multi.Write(context.Background(), nil, f); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, path := range []string{pathA, pathB} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %v", path, err)
+		}
+		if string(got) != content {
+			t.Errorf("unexpected content in %s: %s", path, got)
+		}
+	}
+}
+
+func TestBuildSinksUnsupportedSink(t *testing.T) {
+	cfg := &config.Config{Sinks: "not-a-real-sink"}
+	if _, err := BuildSinks(cfg, nil, "table"); err == nil {
+		t.Fatal("expected an error for an unsupported sink")
+	}
+}
+
+func TestBuildSinksFileMissingPath(t *testing.T) {
+	cfg := &config.Config{Sinks: "file"}
+	if _, err := BuildSinks(cfg, nil, "table"); err == nil {
+		t.Fatal("expected an error when SinkFilePath is unset")
+	}
+}
+
+func TestBuildSinksStdout(t *testing.T) {
+	cfg := &config.Config{Sinks: "stdout, stdout"}
+	sinks, err := BuildSinks(cfg, nil, "table")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sinks) != 2 {
+		t.Fatalf("expected 2 sinks, got %d", len(sinks))
+	}
+	for _, s := range sinks {
+		if !strings.Contains(s.Name(), "stdout") {
+			t.Errorf("unexpected sink name: %s", s.Name())
+		}
+	}
+}
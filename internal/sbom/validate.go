@@ -0,0 +1,181 @@
+// Package sbom provides functionalities to interact with Software Bill of Materials (SBOM).
+package sbom
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/pkg/spdxlicense"
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// Severity classifies a ValidationIssue.
+type Severity string
+
+const (
+	// SeverityError marks an issue severe enough to fail a strict
+	// pipeline.
+	SeverityError Severity = "error"
+	// SeverityWarning marks an issue worth surfacing but not failing on.
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue is a single structural or semantic problem found in an
+// SBOM, scoped to the component/field path it came from.
+type ValidationIssue struct {
+	Path     string
+	Message  string
+	Severity Severity
+}
+
+// ValidationReport is the result of Validate.
+type ValidationReport struct {
+	Format  Format
+	Version string
+	Issues  []ValidationIssue
+}
+
+// HasErrors reports whether the report contains any SeverityError issues,
+// the signal ClickBOM's strict mode (STRICT=true) fails a pipeline on.
+func (r *ValidationReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ValidationReport) addError(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityError})
+}
+
+func (r *ValidationReport) addWarning(path, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{Path: path, Message: fmt.Sprintf(format, args...), Severity: SeverityWarning})
+}
+
+// ValidateOptions configures a Validate call.
+type ValidateOptions struct{}
+
+// knownSpecVersions lists the spec versions Validate recognizes as valid
+// for each format. An unrecognized but non-empty version is a warning, not
+// an error, since ClickBOM would rather flag it than block on a spec
+// release it hasn't been updated for yet.
+var knownSpecVersions = map[Format][]string{
+	FormatCycloneDX: {"1.2", "1.3", "1.4", "1.5", "1.6"},
+	FormatSPDXJSON:  {"SPDX-2.2", "SPDX-2.3"},
+}
+
+// expectedExtensions lists the filename extensions a format is normally
+// shipped with, for the mediatype/extension consistency check.
+var expectedExtensions = map[Format][]string{
+	FormatCycloneDX:    {".json"},
+	FormatSPDXJSON:     {".json"},
+	FormatCycloneDXXML: {".xml"},
+	FormatSPDXTagValue: {".spdx"},
+}
+
+// Validate checks filename's SBOM content against ClickBOM's normalized
+// model and a set of structural/semantic rules: required-field presence
+// for the declared spec version, purl syntax, duplicate bom-ref/SPDXID
+// detection, SPDX license expression structure, and filename-to-format
+// consistency.
+//
+// Validate does not run full JSON Schema validation against the upstream
+// CycloneDX (1.2-1.6) or SPDX (2.2/2.3) schemas: those are large,
+// versioned documents that would need to be vendored and kept in sync
+// with every spec release. The semantic checks below cover the mistakes
+// that matter most to ClickBOM's own pipeline (malformed purls, duplicate
+// refs, missing required fields, format/extension drift) without that
+// maintenance burden.
+func Validate(filename string, opts ValidateOptions) (*ValidationReport, error) {
+	format, err := DetectSBOMFormat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatCycloneDX, FormatSPDXJSON:
+	default:
+		return nil, fmt.Errorf("cannot validate SBOM of format %q; unwrap or convert it to cyclonedx/spdxjson first", format)
+	}
+
+	doc, err := ParseDocument(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SBOM: %w", err)
+	}
+
+	report := &ValidationReport{Format: format, Version: doc.SpecVersion}
+
+	if doc.SpecVersion == "" {
+		report.addError("specVersion", "document does not declare a spec version")
+	} else if versions, ok := knownSpecVersions[format]; ok && !containsString(versions, doc.SpecVersion) {
+		report.addWarning("specVersion", "unrecognized %s spec version %q; expected one of %v", format, doc.SpecVersion, versions)
+	}
+
+	seenIDs := make(map[string]bool, len(doc.Components))
+	for i, c := range doc.Components {
+		path := fmt.Sprintf("components[%d]", i)
+
+		if c.ID == "" {
+			report.addError(path+".id", "component %q has no bom-ref/SPDXID", c.Name)
+		} else if seenIDs[c.ID] {
+			report.addError(path+".id", "duplicate bom-ref/SPDXID: %s", c.ID)
+		} else {
+			seenIDs[c.ID] = true
+		}
+
+		if c.Name == "" {
+			report.addError(path+".name", "component has no name")
+		}
+
+		if c.PURL != "" {
+			if _, err := packageurl.FromString(c.PURL); err != nil {
+				report.addError(path+".purl", "invalid purl %q: %v", c.PURL, err)
+			}
+		}
+
+		for _, license := range c.Licenses {
+			if err := validateLicenseExpression(license); err != nil {
+				report.addWarning(path+".licenses", "%v", err)
+			}
+		}
+	}
+
+	if exts, ok := expectedExtensions[format]; ok {
+		ext := strings.ToLower(filepath.Ext(filename))
+		if !containsString(exts, ext) {
+			report.addWarning("$", "filename %q has extension %q but declared format %s typically uses %v", filepath.Base(filename), ext, format, exts)
+		}
+	}
+
+	return report, nil
+}
+
+// validateLicenseExpression checks that expr parses as a well-formed SPDX
+// license expression (balanced grouping, recognized AND/OR/WITH operators,
+// WITH's single-license-operand rule), by delegating to the same
+// spdxlicense.Parse grammar internal/storage/clickhouse.go uses to split a
+// compound expression into rows. It does not require every leaf identifier
+// to be a known SPDX license ID (see spdxlicense.IsKnownID) - free-text
+// license names and LicenseRef-* identifiers are both valid leaves here.
+func validateLicenseExpression(expr string) error {
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("empty license expression")
+	}
+
+	if _, err := spdxlicense.Parse(expr); err != nil {
+		return fmt.Errorf("invalid SPDX license expression %q: %w", expr, err)
+	}
+	return nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package sbom
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateFlagsMissingBOMRefAndBadPURL(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "sbom.json")
+	content := `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"components": [
+			{"name": "left-pad", "version": "1.0.0", "purl": "not-a-purl"},
+			{"bom-ref": "dup", "name": "a"},
+			{"bom-ref": "dup", "name": "b"}
+		]
+	}`
+	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write sbom: %v", err)
+	}
+
+	report, err := Validate(inputFile, ValidateOptions{})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if !report.HasErrors() {
+		t.Fatalf("expected errors, got %+v", report.Issues)
+	}
+
+	var sawMissingRef, sawDuplicateRef, sawBadPURL bool
+	for _, issue := range report.Issues {
+		switch {
+		case issue.Message == `component "left-pad" has no bom-ref/SPDXID`:
+			sawMissingRef = true
+		case issue.Message == "duplicate bom-ref/SPDXID: dup":
+			sawDuplicateRef = true
+		case issue.Severity == SeverityError && issue.Path == "components[0].purl":
+			sawBadPURL = true
+		}
+	}
+	if !sawMissingRef {
+		t.Error("expected a missing bom-ref issue")
+	}
+	if !sawDuplicateRef {
+		t.Error("expected a duplicate bom-ref issue")
+	}
+	if !sawBadPURL {
+		t.Error("expected an invalid purl issue")
+	}
+}
+
+func TestValidateCleanDocumentHasNoErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	inputFile := filepath.Join(tempDir, "sbom.json")
+	content := `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"components": [
+			{"bom-ref": "a1", "name": "left-pad", "version": "1.0.0", "purl": "pkg:npm/left-pad@1.0.0", "licenses": [{"license": {"id": "MIT"}}]}
+		]
+	}`
+	if err := os.WriteFile(inputFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write sbom: %v", err)
+	}
+
+	report, err := Validate(inputFile, ValidateOptions{})
+	if err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if report.HasErrors() {
+		t.Fatalf("expected no errors, got %+v", report.Issues)
+	}
+}
+
+func TestValidateLicenseExpression(t *testing.T) {
+	if err := validateLicenseExpression("MIT OR Apache-2.0"); err != nil {
+		t.Errorf("expected a valid expression, got error: %v", err)
+	}
+	if err := validateLicenseExpression("GPL-2.0-only WITH Classpath-exception-2.0"); err != nil {
+		t.Errorf("expected a valid WITH expression, got error: %v", err)
+	}
+	if err := validateLicenseExpression("(MIT AND Apache-2.0"); err == nil {
+		t.Error("expected an error for unbalanced parentheses")
+	}
+	if err := validateLicenseExpression("MIT <bad>"); err == nil {
+		t.Error("expected an error for a malformed identifier")
+	}
+	if err := validateLicenseExpression("MIT AND AND Apache-2.0"); err == nil {
+		t.Error("expected an error for a malformed operator sequence")
+	}
+	if err := validateLicenseExpression("(MIT AND Apache-2.0) WITH Classpath-exception-2.0"); err == nil {
+		t.Error("expected an error since WITH must follow a single license, not a compound expression")
+	}
+}
@@ -8,10 +8,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
+	"github.com/ClickHouse/ClickBOM/internal/httpx"
 	"github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
@@ -22,25 +23,58 @@ type WizClient struct {
 	clientID     string
 	clientSecret string
 	reportID     string
-	httpClient   *http.Client
-	accessToken  string
+	httpClient   *http.Client // authenticated API calls; token injected by httpx
+	authClient   *http.Client // unauthenticated token endpoint calls
 }
 
 // NewWizClient creates a new WizClient with the provided configuration.
-func NewWizClient(cfg *config.Config) *WizClient {
-	return &WizClient{
+func NewWizClient(cfg *config.Config) (*WizClient, error) {
+	authClient, err := httpclient.NewClient(httpConfigFrom(cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	w := &WizClient{
 		authEndpoint: cfg.WizAuthEndpoint,
 		apiEndpoint:  cfg.WizAPIEndpoint,
 		clientID:     cfg.WizClientID,
 		clientSecret: cfg.WizClientSecret,
 		reportID:     cfg.WizReportID,
-		httpClient: &http.Client{
-			Timeout: 10 * time.Minute,
-		},
+		authClient:   authClient,
 	}
+	w.httpClient = &http.Client{
+		Timeout:   authClient.Timeout,
+		Transport: httpx.NewTokenTransport(authClient.Transport, w.fetchToken),
+	}
+
+	return w, nil
+}
+
+// Name implements Source.
+func (w *WizClient) Name() string { return "wiz" }
+
+// Validate implements Source.
+func (w *WizClient) Validate() error {
+	if w.clientID == "" || w.clientSecret == "" || w.reportID == "" {
+		return fmt.Errorf("wiz: client ID, client secret, and report ID are required")
+	}
+	return nil
+}
+
+// Fetch implements Source by downloading the configured Wiz report.
+func (w *WizClient) Fetch(ctx context.Context, outputFile string) error {
+	return w.DownloadReport(ctx, outputFile)
+}
+
+func init() {
+	Register("wiz", func(cfg *config.Config) (Source, error) {
+		return NewWizClient(cfg)
+	})
 }
 
-func (w *WizClient) authenticate(ctx context.Context) error {
+// fetchToken implements httpx.TokenFetcher, exchanging the configured
+// client credentials for a Wiz access token.
+func (w *WizClient) fetchToken(ctx context.Context) (string, time.Time, error) {
 	logger.Info("Authenticating with Wiz API")
 
 	data := map[string]string{
@@ -52,20 +86,20 @@ func (w *WizClient) authenticate(ctx context.Context) error {
 
 	jsonData, err := json.Marshal(data)
 	if err != nil {
-		return fmt.Errorf("failed to marshal auth data: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to marshal auth data: %w", err)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", w.authEndpoint, bytes.NewReader(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := w.httpClient.Do(req)
+	resp, err := w.authClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("authentication request failed: %w", err)
+		return "", time.Time{}, fmt.Errorf("authentication request failed: %w", err)
 	}
 	defer func() {
 		if err := resp.Body.Close(); err != nil {
@@ -75,38 +109,41 @@ func (w *WizClient) authenticate(ctx context.Context) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
+		return "", time.Time{}, fmt.Errorf("authentication failed (status %d): %s", resp.StatusCode, string(body))
 	}
 
 	var authResp struct {
 		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return fmt.Errorf("failed to parse auth response: %w", err)
+		return "", time.Time{}, fmt.Errorf("failed to parse auth response: %w", err)
 	}
 
 	if authResp.AccessToken == "" {
-		return fmt.Errorf("no access token in response")
+		return "", time.Time{}, fmt.Errorf("no access token in response")
 	}
 
-	w.accessToken = authResp.AccessToken
 	logger.Success("Wiz authentication successful")
-	return nil
+
+	if authResp.ExpiresIn > 0 {
+		return authResp.AccessToken, time.Now().Add(time.Duration(authResp.ExpiresIn) * time.Second), nil
+	}
+	if expiry, err := httpx.ParseJWTExpiry(authResp.AccessToken); err == nil {
+		return authResp.AccessToken, expiry, nil
+	}
+	return authResp.AccessToken, time.Time{}, nil
 }
 
 // DownloadReport downloads the Wiz report and saves it to the specified output file.
 func (w *WizClient) DownloadReport(ctx context.Context, outputFile string) error {
 	logger.Info("Downloading Wiz report: %s", w.reportID)
 
-	// Authenticate first
-	if err := w.authenticate(ctx); err != nil {
-		return fmt.Errorf("authentication failed: %w", err)
-	}
-
-	// GraphQL query to get download URL
+	// GraphQL query to get the download URL and, when available, a
+	// checksum of the report contents.
 	query := fmt.Sprintf(`{
-        "query": "query ReportDownloadUrl($reportId: ID!) { report(id: $reportId) { lastRun { url } } }",
+        "query": "query ReportDownloadUrl($reportId: ID!) { report(id: $reportId) { lastRun { url sha256 } } }",
         "variables": {
             "reportId": "%s"
         }
@@ -119,7 +156,6 @@ func (w *WizClient) DownloadReport(ctx context.Context, outputFile string) error
 		return fmt.Errorf("failed to create GraphQL request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+w.accessToken)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -137,7 +173,8 @@ func (w *WizClient) DownloadReport(ctx context.Context, outputFile string) error
 		Data struct {
 			Report struct {
 				LastRun struct {
-					URL string `json:"url"`
+					URL    string `json:"url"`
+					SHA256 string `json:"sha256"`
 				} `json:"lastRun"`
 			} `json:"report"`
 		} `json:"data"`
@@ -154,18 +191,23 @@ func (w *WizClient) DownloadReport(ctx context.Context, outputFile string) error
 		return fmt.Errorf("a Wiz GraphQL error has occurred: %s", graphqlResp.Errors[0].Message)
 	}
 
-	downloadURL := graphqlResp.Data.Report.LastRun.URL
-	if downloadURL == "" {
+	lastRun := graphqlResp.Data.Report.LastRun
+	if lastRun.URL == "" {
 		return fmt.Errorf("no download URL found in response")
 	}
 
 	logger.Info("Got download URL from Wiz")
 
+	var checksum string
+	if lastRun.SHA256 != "" {
+		checksum = "sha256:" + lastRun.SHA256
+	}
+
 	// Download the report
-	return w.downloadFromURL(ctx, downloadURL, outputFile)
+	return w.downloadFromURL(ctx, lastRun.URL, outputFile, checksum)
 }
 
-func (w *WizClient) downloadFromURL(ctx context.Context, url, outputFile string) error {
+func (w *WizClient) downloadFromURL(ctx context.Context, url, outputFile, checksum string) error {
 	logger.Info("Downloading Wiz report from URL")
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -173,42 +215,13 @@ func (w *WizClient) downloadFromURL(ctx context.Context, url, outputFile string)
 		return fmt.Errorf("failed to create download request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+w.accessToken)
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := w.httpClient.Do(req)
-	if err != nil {
+	if err := downloadToFile(ctx, w.httpClient, req, outputFile, DownloadOptions{ExpectedChecksum: checksum}); err != nil {
 		return fmt.Errorf("download failed: %w", err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			logger.Warning("Failed to close response body: %v", err)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("download failed (status %d): %s", resp.StatusCode, string(body))
-	}
-
-	// Create output file
-	outFile, err := os.Create(outputFile)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer func() {
-		if err := outFile.Close(); err != nil {
-			logger.Warning("Failed to close file: %v", err)
-		}
-	}()
-
-	// Copy response to file
-	written, err := io.Copy(outFile, resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
 
-	logger.Success("Wiz report downloaded successfully (%d bytes)", written)
+	logger.Success("Wiz report downloaded successfully")
 
 	// Validate JSON
 	if err := validateJSON(outputFile); err != nil {
@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// AWSSecretsManagerResolver resolves secret:// references against AWS
+// Secrets Manager, addressed as "<secret-id-or-arn>#<json-key>". If the
+// secret's value is a plain string rather than JSON, field must be "value".
+type AWSSecretsManagerResolver struct {
+	region string
+	client *secretsmanager.Client
+}
+
+// NewAWSSecretsManagerResolver returns an AWSSecretsManagerResolver for
+// the given region. The underlying client is built lazily on first use.
+func NewAWSSecretsManagerResolver(region string) *AWSSecretsManagerResolver {
+	return &AWSSecretsManagerResolver{region: region}
+}
+
+// Backend implements Resolver.
+func (r *AWSSecretsManagerResolver) Backend() string {
+	return "aws-sm"
+}
+
+// Resolve implements Resolver. locator is a Secrets Manager secret ID or
+// ARN; field is either "value" (for a plain-string secret) or a JSON key
+// within the secret's string value.
+func (r *AWSSecretsManagerResolver) Resolve(ctx context.Context, locator, field string) (string, error) {
+	if err := r.ensureClient(ctx); err != nil {
+		return "", err
+	}
+
+	out, err := r.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(locator),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret %s: %w", locator, err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", locator)
+	}
+
+	if field == "value" {
+		return *out.SecretString, nil
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(*out.SecretString), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object with string values: %w", locator, err)
+	}
+
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %s", field, locator)
+	}
+
+	return value, nil
+}
+
+func (r *AWSSecretsManagerResolver) ensureClient(ctx context.Context) error {
+	if r.client != nil {
+		return nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(r.region))
+	if err != nil {
+		return fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	r.client = secretsmanager.NewFromConfig(cfg)
+	return nil
+}
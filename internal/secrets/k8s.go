@@ -0,0 +1,81 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// K8sResolver resolves secret:// references against the Kubernetes API,
+// addressed as "<namespace>/<name>#<key>".
+type K8sResolver struct {
+	clientset *kubernetes.Clientset
+}
+
+// NewK8sResolver returns a K8sResolver. The Kubernetes clientset is built
+// lazily on first use, so it is safe to construct even when no k8s
+// secret:// refs are in play.
+func NewK8sResolver() *K8sResolver {
+	return &K8sResolver{}
+}
+
+// Backend implements Resolver.
+func (r *K8sResolver) Backend() string {
+	return "k8s"
+}
+
+// Resolve implements Resolver. locator is "<namespace>/<name>".
+func (r *K8sResolver) Resolve(ctx context.Context, locator, field string) (string, error) {
+	namespace, name, found := strings.Cut(locator, "/")
+	if !found || namespace == "" || name == "" {
+		return "", fmt.Errorf("k8s secret locator must be of the form <namespace>/<name>")
+	}
+
+	if err := r.ensureClientset(); err != nil {
+		return "", err
+	}
+
+	secret, err := r.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret %s/%s: %w", namespace, name, err)
+	}
+
+	value, ok := secret.Data[field]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in secret %s/%s", field, namespace, name)
+	}
+
+	return string(value), nil
+}
+
+func (r *K8sResolver) ensureClientset() error {
+	if r.clientset != nil {
+		return nil
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		kubeconfig := os.Getenv("KUBECONFIG")
+		if kubeconfig == "" {
+			kubeconfig = clientcmd.RecommendedHomeFile
+		}
+		restConfig, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return fmt.Errorf("failed to build Kubernetes client config: %w", err)
+		}
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	r.clientset = clientset
+	return nil
+}
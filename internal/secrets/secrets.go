@@ -0,0 +1,85 @@
+// Package secrets resolves secret:// references to their underlying
+// values against pluggable managed secret stores (Kubernetes Secrets,
+// HashiCorp Vault, AWS Secrets Manager), so that internal/config never
+// has to know how a given backend authenticates or fetches a value.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Resolver fetches a secret value from one backend store.
+type Resolver interface {
+	// Backend returns the identifier used in a secret:// reference to
+	// select this resolver, e.g. "k8s", "vault", "aws-sm".
+	Backend() string
+	// Resolve fetches the value addressed by locator, optionally
+	// narrowed to a single field within it (a Secret data key, a Vault
+	// KV field, or a JSON key within an AWS Secrets Manager value).
+	Resolve(ctx context.Context, locator, field string) (string, error)
+}
+
+// Registry dispatches a secret:// reference to the Resolver registered
+// for its backend segment.
+type Registry struct {
+	resolvers map[string]Resolver
+}
+
+// NewRegistry builds a Registry from resolvers, keyed by their Backend().
+func NewRegistry(resolvers ...Resolver) *Registry {
+	byBackend := make(map[string]Resolver, len(resolvers))
+	for _, r := range resolvers {
+		byBackend[r.Backend()] = r
+	}
+
+	return &Registry{resolvers: byBackend}
+}
+
+// Resolve parses a "secret://<backend>/<locator>#<field>" reference and
+// resolves it through the matching backend. Refs whose backend segment
+// does not match any configured resolver fall back to the raw reference
+// unchanged, since it may simply be a literal value that happens to
+// contain "secret://" incidentally.
+func (r *Registry) Resolve(ctx context.Context, ref string) (string, error) {
+	backend, locator, field, ok := ParseRef(ref)
+	if !ok {
+		return ref, nil
+	}
+
+	resolver, ok := r.resolvers[backend]
+	if !ok {
+		return ref, nil
+	}
+
+	value, err := resolver.Resolve(ctx, locator, field)
+	if err != nil {
+		return "", fmt.Errorf("%s resolver: %w", backend, err)
+	}
+
+	return value, nil
+}
+
+// ParseRef splits a "secret://<backend>/<locator>#<field>" reference into
+// its backend, locator, and field parts. ok is false if ref does not have
+// the secret:// scheme or is missing a field.
+func ParseRef(ref string) (backend, locator, field string, ok bool) {
+	const prefix = "secret://"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", "", "", false
+	}
+
+	rest := strings.TrimPrefix(ref, prefix)
+	rest, field, found := strings.Cut(rest, "#")
+	if !found || field == "" {
+		return "", "", "", false
+	}
+
+	backend, locator, found = strings.Cut(rest, "/")
+	if !found || backend == "" || locator == "" {
+		return "", "", "", false
+	}
+
+	return backend, locator, field, true
+}
@@ -0,0 +1,45 @@
+package secrets
+
+import "testing"
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref        string
+		wantOK     bool
+		wantBackend string
+		wantLocator string
+		wantField   string
+	}{
+		{
+			name: "k8s ref", ref: "secret://k8s/my-namespace/my-secret#password", wantOK: true,
+			wantBackend: "k8s", wantLocator: "my-namespace/my-secret", wantField: "password",
+		},
+		{
+			name: "vault ref", ref: "secret://vault/secret/clickbom/prod#mend-user-key", wantOK: true,
+			wantBackend: "vault", wantLocator: "secret/clickbom/prod", wantField: "mend-user-key",
+		},
+		{
+			name: "aws-sm ref", ref: "secret://aws-sm/arn:aws:secretsmanager:us-east-1:123:secret:clickbom#value", wantOK: true,
+			wantBackend: "aws-sm", wantLocator: "arn:aws:secretsmanager:us-east-1:123:secret:clickbom", wantField: "value",
+		},
+		{name: "missing field", ref: "secret://k8s/my-namespace/my-secret", wantOK: false},
+		{name: "missing locator", ref: "secret://k8s#field", wantOK: false},
+		{name: "not a secret ref", ref: "plain-value", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, locator, field, ok := ParseRef(tt.ref)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseRef() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !tt.wantOK {
+				return
+			}
+			if backend != tt.wantBackend || locator != tt.wantLocator || field != tt.wantField {
+				t.Errorf("ParseRef() = (%q, %q, %q), want (%q, %q, %q)", backend, locator, field, tt.wantBackend, tt.wantLocator, tt.wantField)
+			}
+		})
+	}
+}
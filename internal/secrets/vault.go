@@ -0,0 +1,94 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// VaultResolver resolves secret:// references against a HashiCorp Vault
+// KV v2 mount, addressed as "<mount>/<path>#<field>" (e.g.
+// "secret://vault/secret/clickbom/prod#mend-user-key").
+type VaultResolver struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewVaultResolver returns a VaultResolver that authenticates with token
+// against the Vault server at address. It is a no-op to construct when
+// no vault secret:// refs are in play.
+func NewVaultResolver(address, token string) *VaultResolver {
+	return &VaultResolver{
+		address: address,
+		token:   token,
+		client:  &http.Client{},
+	}
+}
+
+// Backend implements Resolver.
+func (r *VaultResolver) Backend() string {
+	return "vault"
+}
+
+// vaultKVv2Response is the subset of the Vault KV v2 read response body
+// ClickBOM needs.
+type vaultKVv2Response struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+// Resolve implements Resolver. locator is the KV v2 mount-relative path,
+// e.g. "secret/clickbom/prod"; field is the key within that secret's data.
+func (r *VaultResolver) Resolve(ctx context.Context, locator, field string) (string, error) {
+	if r.address == "" || r.token == "" {
+		return "", fmt.Errorf("VAULT_ADDR and VAULT_TOKEN must be set to resolve vault secret:// references")
+	}
+
+	mount, path, err := splitVaultLocator(locator)
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", r.address, mount, path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build Vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", r.token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach Vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s/%s", resp.StatusCode, mount, path)
+	}
+
+	var parsed vaultKVv2Response
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("failed to decode Vault response: %w", err)
+	}
+
+	value, ok := parsed.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("field %q not found in vault secret %s/%s", field, mount, path)
+	}
+
+	return value, nil
+}
+
+// splitVaultLocator splits "<mount>/<path...>" into its KV v2 mount and
+// the remaining path within it.
+func splitVaultLocator(locator string) (mount, path string, err error) {
+	for i := 0; i < len(locator); i++ {
+		if locator[i] == '/' {
+			return locator[:i], locator[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("vault locator must be of the form <mount>/<path>")
+}
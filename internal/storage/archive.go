@@ -0,0 +1,416 @@
+package storage
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// archiveTimeLayout is embedded in archive object keys (see
+// archiveTimestamp); retention pruning orders archives by their metadata
+// sidecar's CreatedAt rather than by this key timestamp.
+const archiveTimeLayout = "20060102T150405Z"
+
+// ArchiveMetadata is the JSON sidecar stored alongside every archive, so a
+// historical archive can be inspected or selected for Restore without
+// downloading and decompressing the (possibly large) SBOM itself.
+type ArchiveMetadata struct {
+	OriginalKey  string    `json:"original_key"`
+	Format       string    `json:"format"`
+	QualityScore float64   `json:"quality_score,omitempty"`
+	Source       string    `json:"source"`
+	Repo         string    `json:"repo"`
+	CommitSHA    string    `json:"commit_sha"`
+	CreatedAt    time.Time `json:"created_at"`
+	// ArchiveKey is the object key of the compressed SBOM this sidecar
+	// describes, so List/Restore/prune don't have to guess its
+	// compression suffix.
+	ArchiveKey string `json:"archive_key"`
+}
+
+// ArchiveOptions carries the metadata ArchiveManager.Archive records
+// alongside the archived SBOM. QualityScore is omitted from the sidecar
+// when it is 0 (quality scoring wasn't enabled for the run).
+type ArchiveOptions struct {
+	OriginalKey  string
+	Format       string
+	QualityScore float64
+	Source       string
+	Repo         string
+	CommitSHA    string
+	// Compression selects the archive's compression format: "gzip"
+	// (default), "zip", or "zstd". "zstd" is accepted by config but not
+	// yet implemented by this build - see compressForArchive.
+	Compression string
+}
+
+// ArchiveManager compresses and retains historical copies of processed
+// SBOMs in an ObjectStore, independent of the "latest" object at S3Key.
+// Retention can be age-based (RetentionDays), count-based
+// (RetentionCount), or both - whichever would prune an archive first wins.
+type ArchiveManager struct {
+	store ObjectStore
+}
+
+// NewArchiveManager creates an ArchiveManager backed by the given ObjectStore.
+func NewArchiveManager(store ObjectStore) *ArchiveManager {
+	return &ArchiveManager{store: store}
+}
+
+// Archive compresses localFile per opts.Compression and uploads it under
+// prefix, timestamped so multiple runs don't collide, along with a
+// ".metadata/<same name>.json" sidecar built from opts. retentionDays and
+// retentionCount are applied after upload; either may be 0 to disable that
+// policy.
+func (a *ArchiveManager) Archive(ctx context.Context, localFile, bucket, prefix, sbomFormat string, retentionDays time.Duration, retentionCount int, opts ArchiveOptions) error {
+	logger.Info("Archiving %s SBOM under s3://%s/%s", sbomFormat, bucket, prefix)
+
+	compressedFile, ext, err := compressForArchive(localFile, opts.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to compress SBOM for archival: %w", err)
+	}
+	if compressedFile != localFile {
+		defer os.Remove(compressedFile)
+	}
+
+	now := time.Now().UTC()
+	basename := archiveBasenameFor(now)
+	key := archiveKey(prefix, now, ext)
+	if err := a.store.Upload(ctx, compressedFile, bucket, key, sbomFormat); err != nil {
+		return fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	meta := ArchiveMetadata{
+		OriginalKey:  opts.OriginalKey,
+		Format:       sbomFormat,
+		QualityScore: opts.QualityScore,
+		Source:       opts.Source,
+		Repo:         opts.Repo,
+		CommitSHA:    opts.CommitSHA,
+		CreatedAt:    now,
+		ArchiveKey:   key,
+	}
+	metaFile, err := writeArchiveMetadataFile(meta)
+	if err != nil {
+		return fmt.Errorf("failed to write archive metadata sidecar: %w", err)
+	}
+	defer os.Remove(metaFile)
+
+	metaKey := archiveMetadataKey(prefix, basename)
+	if err := a.store.Upload(ctx, metaFile, bucket, metaKey, "json"); err != nil {
+		return fmt.Errorf("failed to upload archive metadata sidecar: %w", err)
+	}
+
+	logger.Success("Archived SBOM to s3://%s/%s", bucket, key)
+
+	if err := a.prune(ctx, bucket, prefix, retentionDays, retentionCount); err != nil {
+		return fmt.Errorf("failed to prune old archives: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the metadata sidecars of every archive under prefix, newest
+// first, so callers like handleMergeMode can choose historical archives to
+// Restore without downloading every one of them up front.
+func (a *ArchiveManager) List(ctx context.Context, bucket, prefix string) ([]ArchiveMetadata, error) {
+	metaKeys, err := a.store.List(ctx, bucket, archiveMetadataPrefix(prefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archives: %w", err)
+	}
+
+	var metas []ArchiveMetadata
+	for _, metaKey := range metaKeys {
+		meta, err := a.downloadMetadata(ctx, bucket, metaKey)
+		if err != nil {
+			logger.Warning("Failed to read archive metadata %s: %v", metaKey, err)
+			continue
+		}
+		metas = append(metas, meta)
+	}
+
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].CreatedAt.After(metas[j].CreatedAt)
+	})
+
+	return metas, nil
+}
+
+// Restore downloads the archive at meta.ArchiveKey and decompresses it to
+// destFile, so a historical archive can be merged alongside current
+// objects the way handleMergeMode merges live uploads.
+func (a *ArchiveManager) Restore(ctx context.Context, bucket string, meta ArchiveMetadata, destFile string) error {
+	tmp, err := os.CreateTemp("", "clickbom-archive-restore-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := a.store.Download(ctx, bucket, meta.ArchiveKey, tmp.Name()); err != nil {
+		return fmt.Errorf("failed to download archive %s: %w", meta.ArchiveKey, err)
+	}
+
+	if err := decompressArchive(tmp.Name(), meta.ArchiveKey, destFile); err != nil {
+		return fmt.Errorf("failed to decompress archive %s: %w", meta.ArchiveKey, err)
+	}
+
+	return nil
+}
+
+// prune deletes archives under prefix that fall outside retentionDays
+// and/or retentionCount, ordered by each archive's metadata sidecar
+// CreatedAt. Either policy may be 0 to disable it; when both are set, an
+// archive is pruned if it violates either one.
+func (a *ArchiveManager) prune(ctx context.Context, bucket, prefix string, retentionDays time.Duration, retentionCount int) error {
+	if retentionDays <= 0 && retentionCount <= 0 {
+		return nil
+	}
+
+	metas, err := a.List(ctx, bucket, prefix)
+	if err != nil {
+		return err
+	}
+
+	cutoff := time.Now().Add(-retentionDays)
+	var toPrune []ArchiveMetadata
+	for i, meta := range metas {
+		expiredByAge := retentionDays > 0 && meta.CreatedAt.Before(cutoff)
+		expiredByCount := retentionCount > 0 && i >= retentionCount
+		if expiredByAge || expiredByCount {
+			toPrune = append(toPrune, meta)
+		}
+	}
+
+	var pruned int
+	for _, meta := range toPrune {
+		if err := a.store.Delete(ctx, bucket, meta.ArchiveKey); err != nil {
+			logger.Warning("Failed to delete expired archive %s: %v", meta.ArchiveKey, err)
+			continue
+		}
+		if err := a.store.Delete(ctx, bucket, archiveMetadataKey(prefix, archiveBasename(meta.ArchiveKey))); err != nil {
+			logger.Warning("Failed to delete expired archive metadata for %s: %v", meta.ArchiveKey, err)
+		}
+		pruned++
+	}
+
+	if pruned > 0 {
+		logger.Info("Pruned %d expired archive(s) under %s", pruned, prefix)
+	}
+
+	return nil
+}
+
+// downloadMetadata fetches and decodes the metadata sidecar at metaKey.
+func (a *ArchiveManager) downloadMetadata(ctx context.Context, bucket, metaKey string) (ArchiveMetadata, error) {
+	tmp, err := os.CreateTemp("", "clickbom-archive-meta-*.json")
+	if err != nil {
+		return ArchiveMetadata{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := a.store.Download(ctx, bucket, metaKey, tmp.Name()); err != nil {
+		return ArchiveMetadata{}, fmt.Errorf("failed to download metadata: %w", err)
+	}
+
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return ArchiveMetadata{}, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var meta ArchiveMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return ArchiveMetadata{}, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// writeArchiveMetadataFile serializes meta to a new temp file and returns
+// its path.
+func writeArchiveMetadataFile(meta ArchiveMetadata) (string, error) {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "clickbom-archive-meta-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err := tmp.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// archiveBasenameFor returns the "sbom-<timestamp>" basename an archive
+// created at t is keyed on, shared between the archive object and its
+// metadata sidecar.
+func archiveBasenameFor(t time.Time) string {
+	return fmt.Sprintf("sbom-%s", t.Format(archiveTimeLayout))
+}
+
+// archiveKey builds the object key for an archive created at t, with ext
+// ranging over the compression-specific suffix from compressForArchive
+// (e.g. ".json.gz").
+func archiveKey(prefix string, t time.Time, ext string) string {
+	return path.Join(strings.Trim(prefix, "/"), archiveBasenameFor(t)+ext)
+}
+
+// archiveMetadataPrefix returns the key prefix under which every archive's
+// metadata sidecar is stored.
+func archiveMetadataPrefix(prefix string) string {
+	return path.Join(strings.Trim(prefix, "/"), ".metadata") + "/"
+}
+
+// archiveMetadataKey returns the metadata sidecar key for the archive named
+// basename (its key without a compression extension).
+func archiveMetadataKey(prefix, basename string) string {
+	return path.Join(archiveMetadataPrefix(prefix), basename+".json")
+}
+
+// archiveBasename strips an archive key's directory and compression
+// extension, leaving the "sbom-<timestamp>" basename its metadata sidecar
+// is keyed on.
+func archiveBasename(key string) string {
+	base := path.Base(key)
+	for _, ext := range []string{".json.gz", ".json.zip", ".json.zst", ".json"} {
+		if strings.HasSuffix(base, ext) {
+			return strings.TrimSuffix(base, ext)
+		}
+	}
+	return base
+}
+
+// archiveTimestamp extracts the timestamp embedded in an archive key built
+// by Archive.
+func archiveTimestamp(key string) (time.Time, bool) {
+	base := strings.TrimPrefix(archiveBasename(key), "sbom-")
+	ts, err := time.Parse(archiveTimeLayout, base)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return ts, true
+}
+
+// compressForArchive compresses inputFile per compression ("gzip" or
+// "zip"), returning the path to a new temp file and the extension to
+// append to the uploaded key; "zstd" is rejected with an error.
+// compression of "" defaults to gzip, matching
+// ArchiveManager's historical gzip-only behavior.
+func compressForArchive(inputFile, compression string) (outFile, ext string, err error) {
+	switch compression {
+	case "", "gzip":
+		out, err := compressGzip(inputFile)
+		return out, ".json.gz", err
+	case "zip":
+		out, err := compressZip(inputFile)
+		return out, ".json.zip", err
+	case "zstd":
+		return "", "", fmt.Errorf("ARCHIVE_COMPRESSION=zstd is not yet supported by this build; use gzip or zip")
+	default:
+		return "", "", fmt.Errorf("unsupported ARCHIVE_COMPRESSION: %s", compression)
+	}
+}
+
+// decompressArchive reverses compressForArchive, inspecting archiveKey's
+// extension to pick gzip, zip, or a plain copy, and writes the recovered
+// SBOM to destFile.
+func decompressArchive(srcFile, key, destFile string) error {
+	switch {
+	case strings.HasSuffix(key, ".json.gz"):
+		return decompressGzip(srcFile, destFile)
+	case strings.HasSuffix(key, ".json.zip"):
+		return decompressZip(srcFile, destFile)
+	case strings.HasSuffix(key, ".json.zst"):
+		return fmt.Errorf("zstd archives are not yet supported by this build")
+	default:
+		return copyFile(srcFile, destFile)
+	}
+}
+
+// decompressGzip decompresses a gzip-compressed srcFile to destFile.
+func decompressGzip(srcFile, destFile string) error {
+	in, err := os.Open(srcFile)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("failed to open gzip reader: %w", err)
+	}
+	defer gz.Close()
+
+	out, err := os.Create(destFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, gz); err != nil {
+		return fmt.Errorf("failed to decompress archive: %w", err)
+	}
+
+	return nil
+}
+
+// decompressZip extracts the single SBOM entry from a zip-compressed
+// srcFile (as written by compressZip) to destFile.
+func decompressZip(srcFile, destFile string) error {
+	zr, err := zip.OpenReader(srcFile)
+	if err != nil {
+		return fmt.Errorf("failed to open zip archive: %w", err)
+	}
+	defer zr.Close()
+
+	if len(zr.File) == 0 {
+		return fmt.Errorf("zip archive contains no entries")
+	}
+
+	entry, err := zr.File[0].Open()
+	if err != nil {
+		return fmt.Errorf("failed to open zip entry: %w", err)
+	}
+	defer entry.Close()
+
+	out, err := os.Create(destFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, entry); err != nil {
+		return fmt.Errorf("failed to decompress zip entry: %w", err)
+	}
+
+	return nil
+}
+
+// copyFile copies srcFile to destFile verbatim, for uncompressed archives.
+func copyFile(srcFile, destFile string) error {
+	data, err := os.ReadFile(srcFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := os.WriteFile(destFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
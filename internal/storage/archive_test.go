@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchiveKeyRoundTrip(t *testing.T) {
+	now := time.Date(2026, 7, 25, 12, 30, 0, 0, time.UTC)
+	key := archiveKey("github/owner_repo", now, ".json.gz")
+
+	ts, ok := archiveTimestamp(key)
+	if !ok {
+		t.Fatalf("expected to parse timestamp from key %s", key)
+	}
+	if !ts.Equal(now) {
+		t.Errorf("expected %v, got %v", now, ts)
+	}
+}
+
+func TestArchiveTimestampInvalidKey(t *testing.T) {
+	if _, ok := archiveTimestamp("github/owner_repo/not-an-archive.json"); ok {
+		t.Error("expected archiveTimestamp to reject a non-archive key")
+	}
+}
+
+func TestArchiveMetadataKeyMatchesArchiveBasename(t *testing.T) {
+	now := time.Date(2026, 7, 25, 12, 30, 0, 0, time.UTC)
+	key := archiveKey("github/owner_repo", now, ".json.zip")
+
+	metaKey := archiveMetadataKey("github/owner_repo", archiveBasename(key))
+	want := "github/owner_repo/.metadata/sbom-20260725T123000Z.json"
+	if metaKey != want {
+		t.Errorf("archiveMetadataKey() = %q, want %q", metaKey, want)
+	}
+}
+
+func TestCompressForArchiveUnsupported(t *testing.T) {
+	if _, _, err := compressForArchive("unused", "lz4"); err == nil {
+		t.Error("expected an error for an unsupported compression format")
+	}
+	if _, _, err := compressForArchive("unused", "zstd"); err == nil {
+		t.Error("expected zstd to be rejected as not yet supported")
+	}
+}
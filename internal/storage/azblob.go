@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// AzureBlobClient implements ObjectStore against Azure Blob Storage.
+type AzureBlobClient struct {
+	client *azblob.Client
+}
+
+// NewAzureBlobClient creates a new AzureBlobClient for the given storage
+// account. If sasToken is non-empty it is used directly; otherwise
+// sharedKey authenticates with the storage account's shared key.
+func NewAzureBlobClient(ctx context.Context, storageAccount, sasToken, sharedKey string) (*AzureBlobClient, error) {
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", storageAccount)
+
+	if sasToken != "" {
+		client, err := azblob.NewClientWithNoCredential(serviceURL+"?"+sasToken, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Azure Blob client with SAS token: %w", err)
+		}
+		return &AzureBlobClient{client: client}, nil
+	}
+
+	cred, err := azblob.NewSharedKeyCredential(storageAccount, sharedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure shared key credential: %w", err)
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure Blob client: %w", err)
+	}
+
+	return &AzureBlobClient{client: client}, nil
+}
+
+// Upload writes the file at localFile to container/key, where bucket is the
+// Azure Blob container name.
+func (a *AzureBlobClient) Upload(ctx context.Context, localFile, bucket, key, sbomFormat string) error {
+	logger.Info("Uploading %s SBOM to azblob://%s/%s", sbomFormat, bucket, key)
+
+	data, err := os.ReadFile(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	_, err = a.client.UploadBuffer(ctx, bucket, key, data, nil)
+	if err != nil {
+		return fmt.Errorf("failed to upload SBOM to Azure Blob: %w", err)
+	}
+
+	logger.Success("SBOM uploaded successfully to Azure Blob")
+	return nil
+}
+
+// Download reads container/key into localFile.
+func (a *AzureBlobClient) Download(ctx context.Context, bucket, key, localFile string) error {
+	logger.Debug("Downloading azblob://%s/%s to %s", bucket, key, localFile)
+
+	resp, err := a.client.DownloadStream(ctx, bucket, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download from Azure Blob: %w", err)
+	}
+	defer resp.Body.Close()
+
+	file, err := os.Create(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the names of all blobs in the container matching prefix.
+func (a *AzureBlobClient) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	logger.Debug("Listing blobs in azblob://%s with prefix: %s", bucket, prefix)
+
+	var keys []string
+	pager := a.client.NewListBlobsFlatPager(bucket, &azblob.ListBlobsFlatOptions{
+		Prefix: &prefix,
+	})
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list Azure Blob objects: %w", err)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			keys = append(keys, *blob.Name)
+		}
+	}
+
+	logger.Info("Found %d objects in Azure Blob", len(keys))
+	return keys, nil
+}
+
+// Delete removes the blob at container/key.
+func (a *AzureBlobClient) Delete(ctx context.Context, bucket, key string) error {
+	logger.Debug("Deleting azblob://%s/%s", bucket, key)
+
+	_, err := a.client.DeleteBlob(ctx, bucket, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete Azure Blob object: %w", err)
+	}
+
+	return nil
+}
+
+// Head reports whether the blob at container/key exists.
+func (a *AzureBlobClient) Head(ctx context.Context, bucket, key string) (bool, error) {
+	_, err := a.client.ServiceClient().NewContainerClient(bucket).NewBlobClient(key).GetProperties(ctx, nil)
+	if bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to head Azure Blob object: %w", err)
+	}
+
+	return true, nil
+}
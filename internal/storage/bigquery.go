@@ -0,0 +1,200 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// BigQueryStore implements Store against Google BigQuery, using streaming
+// inserts for components and scan run metadata.
+type BigQueryStore struct {
+	client    *bigquery.Client
+	datasetID string
+}
+
+// NewBigQueryStore creates a new BigQueryStore against cfg.BigQueryProjectID
+// and cfg.BigQueryDataset, using Application Default Credentials.
+func NewBigQueryStore(ctx context.Context, cfg *config.Config) (*BigQueryStore, error) {
+	client, err := bigquery.NewClient(ctx, cfg.BigQueryProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create BigQuery client: %w", err)
+	}
+
+	return &BigQueryStore{client: client, datasetID: cfg.BigQueryDataset}, nil
+}
+
+// bigQueryComponentRow is one components row as streamed to BigQuery.
+type bigQueryComponentRow struct {
+	Name              string
+	Version           string
+	Source            string
+	PURL              string
+	CPE               string
+	Hashes            string
+	License           string
+	LicenseExpression string
+	LicenseType       string
+	ExpressionID      string
+	SignerIdentity    string
+	SignerIssuer      string
+	RekorLogIndex     string
+	Vulnerabilities   string
+	MaxCVSS           float32
+	SBOMDigest        string
+	InsertedAt        time.Time
+}
+
+// Save implements bigquery.ValueSaver.
+func (r bigQueryComponentRow) Save() (map[string]bigquery.Value, string, error) {
+	return map[string]bigquery.Value{
+		"name":               r.Name,
+		"version":            r.Version,
+		"source":             r.Source,
+		"purl":               r.PURL,
+		"cpe":                r.CPE,
+		"hashes":             r.Hashes,
+		"license":            r.License,
+		"license_expression": r.LicenseExpression,
+		"license_type":       r.LicenseType,
+		"expression_id":      r.ExpressionID,
+		"signer_identity":    r.SignerIdentity,
+		"signer_issuer":      r.SignerIssuer,
+		"rekor_log_index":    r.RekorLogIndex,
+		"vulnerabilities":    r.Vulnerabilities,
+		"max_cvss":           r.MaxCVSS,
+		"sbom_digest":        r.SBOMDigest,
+		"inserted_at":        r.InsertedAt,
+	}, "", nil
+}
+
+// SetupTable creates tableName in the configured dataset if it does not
+// already exist, inferring the schema from bigQueryComponentRow.
+func (b *BigQueryStore) SetupTable(ctx context.Context, tableName string) error {
+	if err := b.createTableIfNotExists(ctx, tableName, bigQueryComponentRow{}); err != nil {
+		return err
+	}
+	return b.createTableIfNotExists(ctx, scanRunsTableName(tableName), bigQueryScanRunRow{})
+}
+
+func (b *BigQueryStore) createTableIfNotExists(ctx context.Context, tableName string, sample any) error {
+	schema, err := bigquery.InferSchema(sample)
+	if err != nil {
+		return fmt.Errorf("failed to infer BigQuery schema for %s: %w", tableName, err)
+	}
+
+	table := b.client.Dataset(b.datasetID).Table(tableName)
+	if _, err := table.Metadata(ctx); err == nil {
+		return nil
+	}
+
+	if err := table.Create(ctx, &bigquery.TableMetadata{Schema: schema}); err != nil {
+		return fmt.Errorf("failed to create BigQuery table %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// bigQueryScanRunRow mirrors ClickHouse's scan_runs metadata table.
+type bigQueryScanRunRow struct {
+	SBOMDigest     string
+	Source         string
+	Repository     string
+	CommitSHA      string
+	ComponentCount int
+	IngestedAt     time.Time
+}
+
+func (r bigQueryScanRunRow) Save() (map[string]bigquery.Value, string, error) {
+	return map[string]bigquery.Value{
+		"sbom_digest":     r.SBOMDigest,
+		"source":          r.Source,
+		"repository":      r.Repository,
+		"commit_sha":      r.CommitSHA,
+		"component_count": r.ComponentCount,
+		"ingested_at":     r.IngestedAt,
+	}, "", nil
+}
+
+// InsertComponents drains components into tableName via BigQuery's
+// streaming insert API, then records a scan run row.
+func (b *BigQueryStore) InsertComponents(ctx context.Context, tableName string, run RunMetadata, components <-chan Component) error {
+	inserter := b.client.Dataset(b.datasetID).Table(tableName).Inserter()
+
+	var rowCount int
+	var batch []bigQueryComponentRow
+	const batchSize = 500
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := inserter.Put(ctx, batch); err != nil {
+			return fmt.Errorf("failed to stream components into BigQuery table %s: %w", tableName, err)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for comp := range components {
+		vulnJSON, err := vulnerabilitiesJSON(comp.Vulnerabilities)
+		if err != nil {
+			return fmt.Errorf("failed to marshal vulnerabilities: %w", err)
+		}
+
+		batch = append(batch, bigQueryComponentRow{
+			Name:              comp.Name,
+			Version:           comp.Version,
+			Source:            comp.Source,
+			PURL:              comp.PURL,
+			CPE:               comp.CPE,
+			Hashes:            comp.Hashes,
+			License:           comp.License,
+			LicenseExpression: comp.LicenseExpression,
+			LicenseType:       comp.LicenseType,
+			ExpressionID:      comp.ExpressionID,
+			SignerIdentity:    comp.SignerIdentity,
+			SignerIssuer:      comp.SignerIssuer,
+			RekorLogIndex:     comp.RekorLogIndex,
+			Vulnerabilities:   string(vulnJSON),
+			MaxCVSS:           comp.MaxCVSS,
+			SBOMDigest:        run.SBOMDigest,
+			InsertedAt:        time.Now().UTC(),
+		})
+		rowCount++
+
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	scanRunsInserter := b.client.Dataset(b.datasetID).Table(scanRunsTableName(tableName)).Inserter()
+	scanRun := bigQueryScanRunRow{
+		SBOMDigest:     run.SBOMDigest,
+		Source:         run.Source,
+		Repository:     run.Repository,
+		CommitSHA:      run.CommitSHA,
+		ComponentCount: rowCount,
+		IngestedAt:     time.Now().UTC(),
+	}
+	if err := scanRunsInserter.Put(ctx, scanRun); err != nil {
+		logger.Warning("Failed to record scan run metadata: %v", err)
+	}
+
+	return nil
+}
+
+// Close releases the BigQuery client.
+func (b *BigQueryStore) Close() error {
+	return b.client.Close()
+}
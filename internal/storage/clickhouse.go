@@ -4,17 +4,26 @@ package storage
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
 	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/httpclient"
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+	"github.com/ClickHouse/ClickBOM/pkg/attest"
+	"github.com/ClickHouse/ClickBOM/pkg/enrich"
 	"github.com/ClickHouse/ClickBOM/pkg/logger"
+	"github.com/ClickHouse/ClickBOM/pkg/spdxlicense"
 )
 
 // ClickHouseClient handles interactions with ClickHouse database.
@@ -24,21 +33,91 @@ type ClickHouseClient struct {
 	username   string
 	password   string
 	truncate   bool
+	force      bool
 	httpClient *http.Client
+
+	// transport selects how InsertSBOMData talks to ClickHouse: "http"
+	// (legacy TSV over HTTP, the default) or "native" (batched inserts over
+	// the native protocol via clickhouse-go/v2).
+	transport     string
+	nativeConn    driver.Conn
+	batchSize     int
+	insertRetries int
+
+	// SBOM verification: verifyMode is "" (disabled), "optional" (verify
+	// when configured, but ingest unverified SBOMs anyway), or "strict"
+	// (fail ingestion unless verification succeeds). See pkg/attest.
+	verifyMode      string
+	signaturePath   string
+	certificatePath string
+	attestationPath string
+	attestConfig    attest.Config
+
+	// Vulnerability enrichment: joins components against OSV.dev (or an
+	// offline export) before insertion. enrichClient is nil when
+	// VULN_ENRICHMENT is not set, in which case InsertSBOMData skips
+	// enrichment entirely.
+	enrichClient *enrich.Client
 }
 
 // NewClickHouseClient creates a new ClickHouseClient with the provided configuration.
 func NewClickHouseClient(cfg *config.Config) (*ClickHouseClient, error) {
-	return &ClickHouseClient{
-		url:      cfg.ClickHouseURL,
-		database: cfg.ClickHouseDatabase,
-		username: cfg.ClickHouseUsername,
-		password: cfg.ClickHousePassword,
-		truncate: cfg.TruncateTable,
-		httpClient: &http.Client{
-			Timeout: 5 * time.Minute,
+	httpCfg := httpclient.DefaultConfig()
+	httpCfg.Timeout = 5 * time.Minute
+	httpCfg.ProxyURL = cfg.HTTPProxyURL
+	httpCfg.NoProxy = cfg.HTTPNoProxy
+	httpCfg.CABundlePath = cfg.CustomCABundle
+	if cfg.HTTPMaxRetries > 0 {
+		httpCfg.MaxRetries = cfg.HTTPMaxRetries
+	}
+
+	httpClient, err := httpclient.NewClient(httpCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure ClickHouse HTTP client: %w", err)
+	}
+
+	client := &ClickHouseClient{
+		url:             cfg.ClickHouseURL,
+		database:        cfg.ClickHouseDatabase,
+		username:        cfg.ClickHouseUsername,
+		password:        cfg.ClickHousePassword,
+		truncate:        cfg.TruncateTable,
+		force:           cfg.ForceReingest,
+		httpClient:      httpClient,
+		transport:       cfg.ClickHouseTransport,
+		batchSize:       cfg.ClickHouseBatchSize,
+		insertRetries:   cfg.ClickHouseInsertRetries,
+		verifyMode:      cfg.VerifySBOM,
+		signaturePath:   cfg.SBOMSignaturePath,
+		certificatePath: cfg.SBOMCertificatePath,
+		attestationPath: cfg.SBOMAttestationPath,
+		attestConfig: attest.Config{
+			PublicKeyPath:     cfg.CosignPublicKeyPath,
+			AllowedIdentities: splitCSV(cfg.CosignCertIdentities),
+			AllowedIssuer:     cfg.CosignCertOIDCIssuer,
+			RekorURL:          cfg.RekorURL,
 		},
-	}, nil
+	}
+
+	if cfg.VulnEnrichment {
+		client.enrichClient = enrich.NewClient(enrich.Config{
+			OSVEndpoint:    cfg.OSVEndpoint,
+			Concurrency:    cfg.VulnEnrichConcurrency,
+			CacheDir:       cfg.VulnCacheDir,
+			CacheTTL:       time.Duration(cfg.VulnCacheTTLHours) * time.Hour,
+			OfflineZipPath: cfg.OSVOfflineZipPath,
+		})
+	}
+
+	if client.transport == "native" {
+		conn, err := newNativeConn(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure ClickHouse native connection: %w", err)
+		}
+		client.nativeConn = conn
+	}
+
+	return client, nil
 }
 
 func (c *ClickHouseClient) executeQuery(ctx context.Context, query string) error {
@@ -152,9 +231,22 @@ func (c *ClickHouseClient) SetupTable(ctx context.Context, tableName string) err
                 version String,
                 license String,
                 source LowCardinality(String),
+                purl String DEFAULT '',
+                cpe String DEFAULT '',
+                hashes String DEFAULT '',
+                license_expression String DEFAULT '',
+                license_type Enum8('id' = 1, 'name' = 2, 'expression' = 3, 'ref' = 4) DEFAULT 'id',
+                expression_id String DEFAULT '',
+                signer_identity String DEFAULT '',
+                signer_issuer String DEFAULT '',
+                rekor_log_index String DEFAULT '',
+                vulnerabilities Array(Tuple(id String, cvss Float32, severity LowCardinality(String), fixed_version String)) DEFAULT [],
+                max_cvss Float32 DEFAULT 0,
+                sbom_digest String DEFAULT '',
+                component_uid UInt64 MATERIALIZED cityHash64(name, version, purl, sbom_digest),
                 inserted_at DateTime DEFAULT now()
-            ) ENGINE = MergeTree()
-            ORDER BY (name, version, license)
+            ) ENGINE = ReplacingMergeTree(inserted_at)
+            ORDER BY (sbom_digest, name, version, purl)
         `, c.database, tableName)
 
 		if err := c.executeQuery(ctx, createQuery); err != nil {
@@ -166,97 +258,467 @@ func (c *ClickHouseClient) SetupTable(ctx context.Context, tableName string) err
 	return nil
 }
 
+// columnMigration describes a column that may be missing from a table
+// created before it was added to the schema.
+type columnMigration struct {
+	name       string
+	definition string
+}
+
+var clickHouseColumnMigrations = []columnMigration{
+	{name: "source", definition: "LowCardinality(String) DEFAULT 'unknown'"},
+	{name: "purl", definition: "String DEFAULT ''"},
+	{name: "cpe", definition: "String DEFAULT ''"},
+	{name: "hashes", definition: "String DEFAULT ''"},
+	{name: "license_expression", definition: "String DEFAULT ''"},
+	{name: "license_type", definition: "Enum8('id' = 1, 'name' = 2, 'expression' = 3, 'ref' = 4) DEFAULT 'id'"},
+	{name: "expression_id", definition: "String DEFAULT ''"},
+	{name: "signer_identity", definition: "String DEFAULT ''"},
+	{name: "signer_issuer", definition: "String DEFAULT ''"},
+	{name: "rekor_log_index", definition: "String DEFAULT ''"},
+	{name: "vulnerabilities", definition: "Array(Tuple(id String, cvss Float32, severity LowCardinality(String), fixed_version String)) DEFAULT []"},
+	{name: "max_cvss", definition: "Float32 DEFAULT 0"},
+	{name: "sbom_digest", definition: "String DEFAULT ''"},
+}
+
 func (c *ClickHouseClient) checkAndMigrateTable(ctx context.Context, tableName string) error {
-	logger.Info("Checking if table %s needs migration for source column", tableName)
+	logger.Info("Checking if table %s needs migration", tableName)
 
-	// Check if source column exists
-	checkQuery := fmt.Sprintf(
-		"SELECT COUNT(*) FROM system.columns WHERE database='%s' AND table='%s' AND name='source'",
-		c.database, tableName)
+	for _, migration := range clickHouseColumnMigrations {
+		checkQuery := fmt.Sprintf(
+			"SELECT COUNT(*) FROM system.columns WHERE database='%s' AND table='%s' AND name='%s'",
+			c.database, tableName, migration.name)
 
-	result, err := c.queryScalar(ctx, checkQuery)
-	if err != nil {
-		return fmt.Errorf("failed to check column existence: %w", err)
-	}
+		result, err := c.queryScalar(ctx, checkQuery)
+		if err != nil {
+			return fmt.Errorf("failed to check column existence: %w", err)
+		}
+
+		if result != "0" {
+			logger.Debug("%s column already exists in table %s", migration.name, tableName)
+			continue
+		}
 
-	if result == "0" {
-		logger.Info("source column not found, migrating table: %s", tableName)
+		logger.Info("%s column not found, migrating table: %s", migration.name, tableName)
 
 		alterQuery := fmt.Sprintf(
-			"ALTER TABLE %s.%s ADD COLUMN source LowCardinality(String) DEFAULT 'unknown'",
-			c.database, tableName)
+			"ALTER TABLE %s.%s ADD COLUMN %s %s",
+			c.database, tableName, migration.name, migration.definition)
 
 		if err := c.executeQuery(ctx, alterQuery); err != nil {
-			return fmt.Errorf("failed to add source column: %w", err)
+			return fmt.Errorf("failed to add %s column: %w", migration.name, err)
 		}
 
-		logger.Success("source column added to table %s", tableName)
-	} else {
-		logger.Info("source column already exists in table %s", tableName)
+		logger.Success("%s column added to table %s", migration.name, tableName)
+	}
+
+	engineQuery := fmt.Sprintf(
+		"SELECT engine FROM system.tables WHERE database='%s' AND name='%s'",
+		c.database, tableName)
+	if engine, err := c.queryScalar(ctx, engineQuery); err == nil && !strings.Contains(engine, "Replacing") {
+		logger.Warning("Table %s uses engine %s instead of ReplacingMergeTree - re-ingesting the same SBOM will duplicate rows rather than dedup by sbom_digest. ClickHouse can't ALTER a table's engine or ORDER BY key in place; recreate the table to pick up the new schema.", tableName, engine)
 	}
 
 	return nil
 }
 
+// ScanMetadata identifies the CI run an SBOM was ingested from, recorded
+// in the scan_runs table alongside its digest.
+type ScanMetadata struct {
+	Source     string
+	Repository string
+	CommitSHA  string
+}
+
 // InsertSBOMData extracts components from the SBOM and inserts them into the ClickHouse table.
-func (c *ClickHouseClient) InsertSBOMData(ctx context.Context, sbomFile, tableName, sbomFormat string) error {
+// sbomFormat is any format model.Decode accepts ("cyclonedx", "cyclonedx-xml",
+// "spdxjson", "spdx-tagvalue", or "syft"). If verification is configured
+// (see ClickHouseClient.verifyMode), the SBOM is verified before it is
+// parsed; only when verifyMode is "strict" does a failed or missing
+// verification abort ingestion.
+//
+// Ingestion is keyed by the SHA-256 digest of sbomFile: if a scan run with
+// the same digest was already recorded, InsertSBOMData skips re-inserting
+// its components unless c.force (FORCE_REINGEST) is set.
+func (c *ClickHouseClient) InsertSBOMData(ctx context.Context, sbomFile, tableName, sbomFormat string, meta ScanMetadata) error {
 	logger.Info("Extracting components from %s SBOM for ClickHouse", sbomFormat)
 
+	signer, err := c.verifySBOM(ctx, sbomFile)
+	if err != nil {
+		if c.verifyMode == "strict" {
+			return fmt.Errorf("SBOM verification failed: %w", err)
+		}
+		logger.Warning("SBOM verification failed, ingesting unverified (VERIFY_SBOM=%s): %v", c.verifyMode, err)
+	}
+
 	// Read SBOM file
 	data, err := os.ReadFile(sbomFile)
 	if err != nil {
 		return fmt.Errorf("failed to read SBOM file: %w", err)
 	}
+	digest := sha256Hex(data)
 
-	var components []map[string]interface{}
+	scanRunsTable := scanRunsTableName(tableName)
+	if err := c.SetupScanRunsTable(ctx, scanRunsTable); err != nil {
+		return fmt.Errorf("failed to setup scan runs table: %w", err)
+	}
 
-	// Parse based on format
-	switch sbomFormat {
-	case "cyclonedx":
-		var cdx struct {
-			Components []map[string]interface{} `json:"components"`
+	if !c.force {
+		ingested, err := c.digestIngested(ctx, scanRunsTable, digest)
+		if err != nil {
+			return err
 		}
-		if err := json.Unmarshal(data, &cdx); err != nil {
-			return fmt.Errorf("failed to parse CycloneDX: %w", err)
+		if ingested {
+			logger.Info("SBOM digest %s already ingested into %s, skipping (set FORCE_REINGEST=true to re-ingest anyway)", digest, tableName)
+			return nil
 		}
-		components = cdx.Components
+	}
+
+	doc, err := model.Decode(data, sbomFormat)
+	if err != nil {
+		return fmt.Errorf("failed to parse SBOM: %w", err)
+	}
+
+	if len(doc.Components) == 0 {
+		logger.Warning("No components found in SBOM")
+		return nil
+	}
+
+	logger.Info("Found %d components to insert", len(doc.Components))
+
+	vulns := c.enrichComponents(ctx, doc.Components)
+
+	var rows []clickHouseRow
+	for _, comp := range doc.Components {
+		rows = append(rows, buildRows(comp, doc.LicenseRefs, signer, vulns[comp.PURL], digest)...)
+	}
+
+	var insertErr error
+	if c.transport == "native" {
+		insertErr = c.insertComponentsNative(ctx, tableName, rows)
+	} else {
+		insertErr = c.insertComponentsHTTP(ctx, tableName, rows)
+	}
+	if insertErr != nil {
+		return insertErr
+	}
+
+	if err := c.recordScanRun(ctx, scanRunsTable, digest, meta, len(doc.Components)); err != nil {
+		logger.Warning("Failed to record scan run metadata: %v", err)
+	}
 
-	case "spdxjson":
-		var spdx struct {
-			Packages []map[string]interface{} `json:"packages"`
+	return nil
+}
+
+// InsertComponents implements Store, draining components into tableName and
+// recording a scan run for run once the channel is closed. It performs no
+// SBOM verification, enrichment, or digest-based dedup of its own - those
+// are InsertSBOMData's concerns; InsertComponents is the lower-level entry
+// point any Store backend exposes.
+func (c *ClickHouseClient) InsertComponents(ctx context.Context, tableName string, run RunMetadata, components <-chan Component) error {
+	var rows []clickHouseRow
+	for comp := range components {
+		row := clickHouseRow{
+			Name:               comp.Name,
+			Version:            comp.Version,
+			Source:             comp.Source,
+			PURL:               comp.PURL,
+			CPE:                comp.CPE,
+			Hashes:             comp.Hashes,
+			License:            comp.License,
+			LicenseExpression:  comp.LicenseExpression,
+			LicenseType:        comp.LicenseType,
+			ExpressionID:       comp.ExpressionID,
+			SignerIdentity:     comp.SignerIdentity,
+			SignerIssuer:       comp.SignerIssuer,
+			RekorLogIndex:      comp.RekorLogIndex,
+			MaxCVSS:            comp.MaxCVSS,
+			SBOMDigest:         run.SBOMDigest,
 		}
-		if err := json.Unmarshal(data, &spdx); err != nil {
-			return fmt.Errorf("failed to parse SPDX: %w", err)
+		if len(comp.Vulnerabilities) > 0 {
+			row.Vulnerabilities = make([]vulnRow, len(comp.Vulnerabilities))
+			for i, v := range comp.Vulnerabilities {
+				row.Vulnerabilities[i] = vulnRow{ID: v.ID, CVSS: v.CVSS, Severity: v.Severity, FixedVersion: v.FixedVersion}
+			}
 		}
-		components = spdx.Packages
+		rows = append(rows, row)
+	}
 
-	default:
-		return fmt.Errorf("unsupported SBOM format: %s", sbomFormat)
+	var insertErr error
+	if c.transport == "native" {
+		insertErr = c.insertComponentsNative(ctx, tableName, rows)
+	} else {
+		insertErr = c.insertComponentsHTTP(ctx, tableName, rows)
+	}
+	if insertErr != nil {
+		return insertErr
 	}
 
-	if len(components) == 0 {
-		logger.Warning("No components found in SBOM")
+	scanRunsTable := scanRunsTableName(tableName)
+	if err := c.SetupScanRunsTable(ctx, scanRunsTable); err != nil {
+		return fmt.Errorf("failed to setup scan runs table: %w", err)
+	}
+	if err := c.recordScanRun(ctx, scanRunsTable, run.SBOMDigest, run.ScanMetadata, len(rows)); err != nil {
+		logger.Warning("Failed to record scan run metadata: %v", err)
+	}
+
+	return nil
+}
+
+// Close implements Store, closing the native ClickHouse connection if one
+// was opened (CLICKHOUSE_TRANSPORT=native). It is a no-op over the HTTP
+// transport, which holds no persistent connection.
+func (c *ClickHouseClient) Close() error {
+	if c.nativeConn != nil {
+		return c.nativeConn.Close()
+	}
+	return nil
+}
+
+// digestIngested reports whether scanRunsTable already has a scan run
+// recorded for digest.
+func (c *ClickHouseClient) digestIngested(ctx context.Context, scanRunsTable, digest string) (bool, error) {
+	query := fmt.Sprintf(
+		"SELECT COUNT(*) FROM %s.%s WHERE sbom_digest = '%s'",
+		c.database, scanRunsTable, escapeClickHouseString(digest))
+
+	result, err := c.queryScalar(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existing scan runs: %w", err)
+	}
+	return result != "0", nil
+}
+
+// sha256Hex returns the hex-encoded SHA-256 digest of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifySBOM runs the cryptographic verification configured for c, if any.
+// It returns (nil, nil) when verification is disabled (c.verifyMode == "").
+func (c *ClickHouseClient) verifySBOM(ctx context.Context, sbomFile string) (*attest.Result, error) {
+	if c.verifyMode == "" {
+		return nil, nil
+	}
+
+	if c.attestationPath != "" {
+		return attest.VerifyAttestation(ctx, sbomFile, c.attestationPath, c.certificatePath, c.attestConfig)
+	}
+	if c.signaturePath != "" {
+		return attest.VerifyBlob(ctx, sbomFile, c.signaturePath, c.certificatePath, c.attestConfig)
+	}
+
+	return nil, fmt.Errorf("VERIFY_SBOM=%s but neither SBOM_SIGNATURE_PATH nor SBOM_ATTESTATION_PATH is set", c.verifyMode)
+}
+
+// enrichComponents looks up known vulnerabilities for comps' purls via
+// pkg/enrich, if VULN_ENRICHMENT is configured. Enrichment failures are
+// logged and otherwise ignored - a vulnerability feed outage should not
+// block SBOM ingestion.
+func (c *ClickHouseClient) enrichComponents(ctx context.Context, comps []model.Component) map[string][]enrich.Vulnerability {
+	if c.enrichClient == nil {
+		return nil
+	}
+
+	var packages []enrich.Package
+	for _, comp := range comps {
+		if comp.PURL != "" {
+			packages = append(packages, enrich.Package{PURL: comp.PURL})
+		}
+	}
+	if len(packages) == 0 {
 		return nil
 	}
 
-	logger.Info("Found %d components to insert", len(components))
+	vulns, err := c.enrichClient.Enrich(ctx, packages)
+	if err != nil {
+		logger.Warning("Vulnerability enrichment failed, ingesting without it: %v", err)
+		return nil
+	}
+
+	return vulns
+}
+
+// splitCSV splits a comma-separated string into its trimmed, non-empty
+// parts.
+func splitCSV(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// clickHouseRow is one row as written to the ClickHouse components table.
+// A single SBOM component expands into multiple rows when its license is a
+// compound SPDX expression (e.g. "MIT OR Apache-2.0") - one row per leaf
+// license, all sharing the same ExpressionID so the original grouping can
+// be reconstructed.
+type clickHouseRow struct {
+	Name, Version, Source, PURL, CPE, Hashes string
+	License                                  string
+	LicenseExpression                        string
+	LicenseType                              string
+	ExpressionID                             string
+	SignerIdentity                           string
+	SignerIssuer                             string
+	RekorLogIndex                            string
+	Vulnerabilities                          []vulnRow
+	MaxCVSS                                  float32
+	SBOMDigest                               string
+}
+
+// vulnRow is one entry in a clickHouseRow's Vulnerabilities, matching the
+// table's vulnerabilities Array(Tuple(id, cvss, severity, fixed_version))
+// column field-for-field.
+type vulnRow struct {
+	ID           string
+	CVSS         float32
+	Severity     string
+	FixedVersion string
+}
+
+// buildRows extracts one component's identifying fields plus its license,
+// expanding a compound SPDX expression into one row per leaf license.
+// licenseRefs maps SPDX "LicenseRef-*" IDs to their human readable name,
+// from the SBOM document's LicenseRefs (see model.Document). signer carries
+// the cryptographic verification result for the whole SBOM (nil if the SBOM
+// wasn't verified) and is stamped onto every row produced here. vulns carries
+// the component's known vulnerabilities, if any (see pkg/enrich). digest is
+// the SHA-256 digest of the source SBOM file, stamped onto every row so
+// ReplacingMergeTree can dedup re-ingested SBOMs by (sbom_digest, name,
+// version, purl).
+func buildRows(comp model.Component, licenseRefs map[string]string, signer *attest.Result, vulns []enrich.Vulnerability, digest string) []clickHouseRow {
+	name := comp.Name
+	if name == "" {
+		name = "unknown"
+	}
+	version := comp.Version
+	if version == "" {
+		version = "unknown"
+	}
+	source := comp.Properties["source"]
+	if source == "" {
+		source = "unknown"
+	}
+
+	base := clickHouseRow{
+		Name:       name,
+		Version:    version,
+		Source:     source,
+		PURL:       comp.PURL,
+		CPE:        comp.CPE,
+		Hashes:     joinHashes(comp.Hashes),
+		SBOMDigest: digest,
+	}
+	if signer != nil {
+		base.SignerIdentity = signer.SignerIdentity
+		base.SignerIssuer = signer.SignerIssuer
+		base.RekorLogIndex = signer.RekorLogIndex
+	}
+	if len(vulns) > 0 {
+		base.Vulnerabilities = make([]vulnRow, len(vulns))
+		for i, v := range vulns {
+			base.Vulnerabilities[i] = vulnRow{ID: v.ID, CVSS: v.CVSS, Severity: v.Severity, FixedVersion: v.FixedVersion}
+		}
+		base.MaxCVSS = enrich.MaxCVSS(vulns)
+	}
+
+	expression := extractLicenseExpression(comp)
+	if expression == "unknown" {
+		base.License = "unknown"
+		base.LicenseType = "id"
+		return []clickHouseRow{base}
+	}
+	base.LicenseExpression = expression
+
+	node, err := spdxlicense.Parse(expression)
+	if err != nil {
+		// Not a valid SPDX expression after all (e.g. a free-text license
+		// name) - fall back to a single row carrying the raw string.
+		base.License = expression
+		base.LicenseExpression = ""
+		base.LicenseType = "name"
+		return []clickHouseRow{base}
+	}
+
+	leaves := spdxlicense.Leaves(node)
+	expressionID := ""
+	if len(leaves) > 1 {
+		expressionID = hashExpression(node.String())
+	}
+
+	rows := make([]clickHouseRow, 0, len(leaves))
+	for _, leaf := range leaves {
+		row := base
+		row.ExpressionID = expressionID
+		row.License = leaf.ID
+		switch {
+		case leaf.Ref:
+			row.LicenseType = "ref"
+			if refName, ok := licenseRefs[leaf.ID]; ok && refName != "" {
+				row.License = fmt.Sprintf("%s (%s)", leaf.ID, refName)
+			}
+		case spdxlicense.IsKnownID(leaf.ID):
+			row.LicenseType = "id"
+		default:
+			row.LicenseType = "name"
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
 
+// hashExpression returns a short, stable grouping key for a canonical SPDX
+// expression, so rows produced from the same compound license can be
+// reassociated later.
+func hashExpression(canonical string) string {
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:8])
+}
+
+// extractLicenseExpression returns a component's license as a single SPDX
+// expression string: its lone Licenses entry as-is, multiple entries
+// OR-joined (CycloneDX's licenses[] array lists alternative choices), or
+// "unknown" if it has none. The result may or may not parse as valid SPDX -
+// callers fall back to treating it as a free-text name when it doesn't.
+func extractLicenseExpression(comp model.Component) string {
+	switch len(comp.Licenses) {
+	case 0:
+		return "unknown"
+	case 1:
+		return comp.Licenses[0]
+	default:
+		return "(" + strings.Join(comp.Licenses, " OR ") + ")"
+	}
+}
+
+// insertComponentsHTTP inserts rows using the legacy HTTP+TSV path, kept
+// for deployments that haven't set CLICKHOUSE_TRANSPORT=native. Backslashes,
+// tabs, and newlines in values are escaped per ClickHouse's TSV format (see
+// tsvEscape); every value is sent as text, whereas insertComponentsNative
+// appends typed columns.
+func (c *ClickHouseClient) insertComponentsHTTP(ctx context.Context, tableName string, rows []clickHouseRow) error {
 	// Build TSV data
 	var tsvData bytes.Buffer
-	for _, comp := range components {
-		name := getStringField(comp, "name", "unknown")
-		version := getStringField(comp, "version", "unknown")
-		license := extractLicense(comp)
-		source := getStringField(comp, "source", "unknown")
-
-		fmt.Fprintf(&tsvData, "%s\t%s\t%s\t%s\n", name, version, license, source)
+	for _, row := range rows {
+		fmt.Fprintf(&tsvData, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%g\t%s\n",
+			tsvEscape(row.Name), tsvEscape(row.Version), tsvEscape(row.License), tsvEscape(row.Source),
+			tsvEscape(row.PURL), tsvEscape(row.CPE), tsvEscape(row.Hashes),
+			tsvEscape(row.LicenseExpression), tsvEscape(row.LicenseType), tsvEscape(row.ExpressionID),
+			tsvEscape(row.SignerIdentity), tsvEscape(row.SignerIssuer), tsvEscape(row.RekorLogIndex),
+			tsvEscape(formatVulnerabilities(row.Vulnerabilities)), row.MaxCVSS, tsvEscape(row.SBOMDigest))
 	}
 
 	// Insert data
 	insertURL := fmt.Sprintf("%s/?query=%s",
 		c.url,
 		url.QueryEscape(fmt.Sprintf(
-			"INSERT INTO %s.%s (name, version, license, source) FORMAT TSV",
+			"INSERT INTO %s.%s (name, version, license, source, purl, cpe, hashes, license_expression, license_type, expression_id, signer_identity, signer_issuer, rekor_log_index, vulnerabilities, max_cvss, sbom_digest) FORMAT TSV",
 			c.database, tableName)))
 
 	req, err := http.NewRequestWithContext(ctx, "POST", insertURL, &tsvData)
@@ -285,41 +747,55 @@ func (c *ClickHouseClient) InsertSBOMData(ctx context.Context, sbomFile, tableNa
 		return fmt.Errorf("insert failed (status %d): %s", resp.StatusCode, string(body))
 	}
 
-	logger.Success("Inserted %d components into ClickHouse table %s", len(components), tableName)
+	logger.Success("Inserted %d rows into ClickHouse table %s", len(rows), tableName)
 	return nil
 }
 
-func getStringField(m map[string]interface{}, key, defaultVal string) string {
-	if val, ok := m[key]; ok {
-		if str, ok := val.(string); ok {
-			return str
-		}
-	}
-	return defaultVal
+// tsvEscape escapes the backslashes, tabs, and newlines ClickHouse's TSV
+// format treats specially, so field values containing them don't shift
+// columns.
+func tsvEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "\t", "\\t")
+	s = strings.ReplaceAll(s, "\n", "\\n")
+	return s
 }
 
-func extractLicense(comp map[string]interface{}) string {
-	// Try CycloneDX licenses array
-	if licenses, ok := comp["licenses"].([]interface{}); ok && len(licenses) > 0 {
-		if lic, ok := licenses[0].(map[string]interface{}); ok {
-			if license, ok := lic["license"].(map[string]interface{}); ok {
-				if id, ok := license["id"].(string); ok && id != "" {
-					return id
-				}
-				if name, ok := license["name"].(string); ok && name != "" {
-					return name
-				}
-			}
-		}
+// formatVulnerabilities renders vulns in ClickHouse's text representation
+// for an Array(Tuple(...)) column, e.g. "[('CVE-1',9.8,'CRITICAL','1.2.3')]",
+// for the TSV insert path. insertComponentsNative passes []vulnRow to
+// batch.Append directly and doesn't need this.
+func formatVulnerabilities(vulns []vulnRow) string {
+	parts := make([]string, len(vulns))
+	for i, v := range vulns {
+		parts[i] = fmt.Sprintf("('%s',%g,'%s','%s')",
+			escapeClickHouseString(v.ID), v.CVSS, escapeClickHouseString(v.Severity), escapeClickHouseString(v.FixedVersion))
 	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
 
-	// Try SPDX fields
-	if concluded, ok := comp["licenseConcluded"].(string); ok && concluded != "" {
-		return concluded
-	}
-	if declared, ok := comp["licenseDeclared"].(string); ok && declared != "" {
-		return declared
+// escapeClickHouseString escapes a string for use inside a quoted literal in
+// ClickHouse's text format (e.g. a Tuple rendered for TSV insertion), distinct
+// from tsvEscape which only handles the outer TSV field delimiters.
+func escapeClickHouseString(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "'", "\\'")
+	return s
+}
+
+// joinHashes renders a component's hashes as "alg:value" pairs joined by
+// ";", in a stable order so repeated runs over the same component produce
+// identical output.
+func joinHashes(hashes map[string]string) string {
+	algs := make([]string, 0, len(hashes))
+	for alg := range hashes {
+		algs = append(algs, alg)
 	}
+	sort.Strings(algs)
 
-	return "unknown"
+	parts := make([]string, 0, len(algs))
+	for _, alg := range algs {
+		parts = append(parts, fmt.Sprintf("%s:%s", alg, hashes[alg]))
+	}
+	return strings.Join(parts, ";")
 }
@@ -84,7 +84,7 @@ func TestClickHouseIntegration(t *testing.T) {
 		defer os.Remove(testSBOM)
 
 		// Insert data
-		err := chClient.InsertSBOMData(ctx, testSBOM, tableName, "cyclonedx")
+		err := chClient.InsertSBOMData(ctx, testSBOM, tableName, "cyclonedx", ScanMetadata{Source: "github"})
 		if err != nil {
 			t.Fatalf("Failed to insert data: %v", err)
 		}
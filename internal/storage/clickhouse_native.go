@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// newNativeConn opens a pooled connection to ClickHouse over the native
+// protocol, honoring cfg's TLS and compression settings.
+func newNativeConn(cfg *config.Config) (driver.Conn, error) {
+	if cfg.ClickHouseNativeAddr == "" {
+		return nil, fmt.Errorf("CLICKHOUSE_NATIVE_ADDR is required for CLICKHOUSE_TRANSPORT=native")
+	}
+
+	opts := &clickhouse.Options{
+		Addr: []string{cfg.ClickHouseNativeAddr},
+		Auth: clickhouse.Auth{
+			Database: cfg.ClickHouseDatabase,
+			Username: cfg.ClickHouseUsername,
+			Password: cfg.ClickHousePassword,
+		},
+	}
+
+	if cfg.ClickHouseTLS {
+		opts.TLS = &tls.Config{}
+	}
+
+	switch cfg.ClickHouseCompression {
+	case "", "lz4":
+		opts.Compression = &clickhouse.Compression{Method: clickhouse.CompressionLZ4}
+	case "none":
+		// No compression.
+	default:
+		return nil, fmt.Errorf("unsupported CLICKHOUSE_COMPRESSION: %s", cfg.ClickHouseCompression)
+	}
+
+	conn, err := clickhouse.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open native connection: %w", err)
+	}
+
+	return conn, nil
+}
+
+// insertComponentsNative sends rows into tableName using prepared INSERT
+// batches of c.batchSize rows, and retries a failed batch send with
+// exponential backoff before giving up. rows is the full row list, built in
+// memory by the caller before the first batch is sent.
+func (c *ClickHouseClient) insertComponentsNative(ctx context.Context, tableName string, rows []clickHouseRow) error {
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO %s.%s (name, version, license, source, purl, cpe, hashes, license_expression, license_type, expression_id, signer_identity, signer_issuer, rekor_log_index, vulnerabilities, max_cvss, sbom_digest) VALUES",
+		c.database, tableName)
+
+	batchSize := c.batchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var inserted int
+	for start := 0; start < len(rows); start += batchSize {
+		end := start + batchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunk := rows[start:end]
+
+		if err := c.sendBatchWithRetry(ctx, insertQuery, chunk); err != nil {
+			return fmt.Errorf("failed to insert batch [%d:%d): %w", start, end, err)
+		}
+		inserted += len(chunk)
+	}
+
+	logger.Success("Inserted %d rows into ClickHouse table %s", inserted, tableName)
+	return nil
+}
+
+// sendBatchWithRetry prepares and sends one batch, retrying on failure with
+// exponential backoff up to c.insertRetries times.
+func (c *ClickHouseClient) sendBatchWithRetry(ctx context.Context, insertQuery string, chunk []clickHouseRow) error {
+	maxRetries := c.insertRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			logger.Warning("Retrying ClickHouse batch insert (attempt %d/%d) after %v: %v", attempt+1, maxRetries+1, backoff, lastErr)
+			time.Sleep(backoff)
+		}
+
+		if err := c.sendBatch(ctx, insertQuery, chunk); err != nil {
+			lastErr = err
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("exhausted retries: %w", lastErr)
+}
+
+func (c *ClickHouseClient) sendBatch(ctx context.Context, insertQuery string, chunk []clickHouseRow) error {
+	batch, err := c.nativeConn.PrepareBatch(ctx, insertQuery)
+	if err != nil {
+		return fmt.Errorf("failed to prepare batch: %w", err)
+	}
+
+	for _, row := range chunk {
+		if err := batch.Append(row.Name, row.Version, row.License, row.Source, row.PURL, row.CPE, row.Hashes,
+			row.LicenseExpression, row.LicenseType, row.ExpressionID,
+			row.SignerIdentity, row.SignerIssuer, row.RekorLogIndex,
+			row.Vulnerabilities, row.MaxCVSS, row.SBOMDigest); err != nil {
+			return fmt.Errorf("failed to append row: %w", err)
+		}
+	}
+
+	if err := batch.Send(); err != nil {
+		return fmt.Errorf("failed to send batch: %w", err)
+	}
+
+	return nil
+}
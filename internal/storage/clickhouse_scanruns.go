@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// scanRunsTableName returns the scan_runs metadata table name for a given
+// components table, e.g. "sbom_data" -> "sbom_data_scan_runs".
+func scanRunsTableName(tableName string) string {
+	return tableName + "_scan_runs"
+}
+
+// SetupScanRunsTable prepares the ClickHouse scan_runs table, which records
+// one row per ingested SBOM digest so InsertSBOMData can detect and skip
+// re-ingesting an SBOM it has already seen.
+func (c *ClickHouseClient) SetupScanRunsTable(ctx context.Context, tableName string) error {
+	checkQuery := fmt.Sprintf(
+		"SELECT COUNT(*) FROM system.tables WHERE database='%s' AND name='%s'",
+		c.database, tableName)
+
+	result, err := c.queryScalar(ctx, checkQuery)
+	if err != nil {
+		return fmt.Errorf("failed to check table existence: %w", err)
+	}
+
+	if result == "1" {
+		logger.Debug("Table %s already exists", tableName)
+		return nil
+	}
+
+	logger.Info("Creating new table: %s", tableName)
+	createQuery := fmt.Sprintf(`
+        CREATE TABLE %s.%s (
+            sbom_digest String,
+            source LowCardinality(String) DEFAULT '',
+            repository String DEFAULT '',
+            commit_sha String DEFAULT '',
+            component_count UInt32 DEFAULT 0,
+            ingested_at DateTime DEFAULT now()
+        ) ENGINE = MergeTree()
+        ORDER BY (sbom_digest, ingested_at)
+    `, c.database, tableName)
+
+	if err := c.executeQuery(ctx, createQuery); err != nil {
+		return fmt.Errorf("failed to create scan runs table: %w", err)
+	}
+
+	logger.Success("Table %s created successfully", tableName)
+	return nil
+}
+
+// recordScanRun inserts one row into tableName for a completed ingestion.
+func (c *ClickHouseClient) recordScanRun(ctx context.Context, tableName, digest string, meta ScanMetadata, componentCount int) error {
+	tsvRow := fmt.Sprintf("%s\t%s\t%s\t%s\t%d\n",
+		tsvEscape(digest), tsvEscape(meta.Source), tsvEscape(meta.Repository), tsvEscape(meta.CommitSHA), componentCount)
+
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO %s.%s (sbom_digest, source, repository, commit_sha, component_count) FORMAT TSV\n%s",
+		c.database, tableName, tsvRow)
+
+	if err := c.executeQuery(ctx, insertQuery); err != nil {
+		return fmt.Errorf("failed to insert scan run: %w", err)
+	}
+
+	logger.Debug("Recorded scan run %s into %s", digest, tableName)
+	return nil
+}
+
+// PruneOlderThan sets a TTL on tableName's components and scan_runs tables
+// so ClickHouse asynchronously drops rows older than retention, rather than
+// issuing a synchronous DELETE.
+func (c *ClickHouseClient) PruneOlderThan(ctx context.Context, tableName string, retention time.Duration) error {
+	days := int(retention.Hours() / 24)
+	if days < 1 {
+		days = 1
+	}
+
+	for _, table := range []string{tableName, scanRunsTableName(tableName)} {
+		ttlColumn := "inserted_at"
+		if table == scanRunsTableName(tableName) {
+			ttlColumn = "ingested_at"
+		}
+
+		query := fmt.Sprintf(
+			"ALTER TABLE %s.%s MODIFY TTL %s + INTERVAL %d DAY",
+			c.database, table, ttlColumn, days)
+
+		if err := c.executeQuery(ctx, query); err != nil {
+			return fmt.Errorf("failed to set TTL on %s: %w", table, err)
+		}
+	}
+
+	logger.Success("Set a %d day retention TTL on %s and its scan runs table", days, tableName)
+	return nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/internal/sbom/score"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// scoreCategories lists the score.Category values in the fixed order used
+// for the sbom_scores table columns.
+var scoreCategories = []score.Category{
+	score.CategoryNTIA,
+	score.CategoryStructural,
+	score.CategorySemantic,
+	score.CategorySharing,
+}
+
+// SetupScoresTable prepares the ClickHouse sbom_scores table for insertion.
+func (c *ClickHouseClient) SetupScoresTable(ctx context.Context, tableName string) error {
+	logger.Info("Setting up ClickHouse scores table: %s", tableName)
+
+	checkQuery := fmt.Sprintf(
+		"SELECT COUNT(*) FROM system.tables WHERE database='%s' AND name='%s'",
+		c.database, tableName)
+
+	result, err := c.queryScalar(ctx, checkQuery)
+	if err != nil {
+		return fmt.Errorf("failed to check table existence: %w", err)
+	}
+
+	if result == "1" {
+		logger.Info("Table %s already exists", tableName)
+		return nil
+	}
+
+	logger.Info("Creating new table: %s", tableName)
+	createQuery := fmt.Sprintf(`
+        CREATE TABLE %s.%s (
+            source LowCardinality(String),
+            overall_score Float64,
+            ntia_score Float64,
+            structural_score Float64,
+            semantic_score Float64,
+            sharing_score Float64,
+            component_count UInt32,
+            inserted_at DateTime DEFAULT now()
+        ) ENGINE = MergeTree()
+        ORDER BY (source, inserted_at)
+    `, c.database, tableName)
+
+	if err := c.executeQuery(ctx, createQuery); err != nil {
+		return fmt.Errorf("failed to create scores table: %w", err)
+	}
+
+	logger.Success("Table %s created successfully", tableName)
+	return nil
+}
+
+// InsertScoreData inserts a single SBOM quality report into the scores table.
+func (c *ClickHouseClient) InsertScoreData(ctx context.Context, report *score.Report, tableName, source string) error {
+	row := []string{
+		source,
+		fmt.Sprintf("%f", report.Overall),
+	}
+	for _, category := range scoreCategories {
+		row = append(row, fmt.Sprintf("%f", report.Categories[category]))
+	}
+	row = append(row, fmt.Sprintf("%d", report.Components))
+
+	tsvRow := strings.Join(row, "\t") + "\n"
+
+	insertQuery := fmt.Sprintf(
+		"INSERT INTO %s.%s (source, overall_score, ntia_score, structural_score, semantic_score, sharing_score, component_count) FORMAT TSV\n%s",
+		c.database, tableName, tsvRow)
+
+	if err := c.executeQuery(ctx, insertQuery); err != nil {
+		return fmt.Errorf("failed to insert score data: %w", err)
+	}
+
+	logger.Success("Inserted SBOM quality score into ClickHouse table %s", tableName)
+	return nil
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// FileObjectStore implements ObjectStore against a local directory,
+// treating "bucket" as a subdirectory of baseDir and "key" as a path
+// within it. It exists for local development and CI runs without access
+// to a cloud object store.
+type FileObjectStore struct {
+	baseDir string
+}
+
+// NewFileObjectStore creates a FileObjectStore rooted at baseDir.
+func NewFileObjectStore(baseDir string) (*FileObjectStore, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("file storage backend requires a base directory")
+	}
+	if err := os.MkdirAll(baseDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create file storage base directory: %w", err)
+	}
+	return &FileObjectStore{baseDir: baseDir}, nil
+}
+
+func (f *FileObjectStore) path(bucket, key string) string {
+	return filepath.Join(f.baseDir, bucket, key)
+}
+
+// Upload copies localFile to baseDir/bucket/key.
+func (f *FileObjectStore) Upload(_ context.Context, localFile, bucket, key, sbomFormat string) error {
+	dst := f.path(bucket, key)
+	logger.Info("Uploading %s SBOM to file://%s", sbomFormat, dst)
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+
+	in, err := os.Open(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to write destination file: %w", err)
+	}
+
+	logger.Success("SBOM uploaded successfully to %s", dst)
+	return nil
+}
+
+// Download copies baseDir/bucket/key to localFile.
+func (f *FileObjectStore) Download(_ context.Context, bucket, key, localFile string) error {
+	src := f.path(bucket, key)
+	logger.Debug("Downloading file://%s to %s", src, localFile)
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open source file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the slash-separated paths of every file under
+// baseDir/bucket whose path has the given prefix.
+func (f *FileObjectStore) List(_ context.Context, bucket, prefix string) ([]string, error) {
+	root := filepath.Join(f.baseDir, bucket)
+
+	var keys []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(rel, prefix) {
+			keys = append(keys, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Delete removes baseDir/bucket/key.
+func (f *FileObjectStore) Delete(_ context.Context, bucket, key string) error {
+	if err := os.Remove(f.path(bucket, key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// Head reports whether baseDir/bucket/key exists.
+func (f *FileObjectStore) Head(_ context.Context, bucket, key string) (bool, error) {
+	_, err := os.Stat(f.path(bucket, key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to stat file: %w", err)
+	}
+	return true, nil
+}
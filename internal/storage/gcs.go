@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// GCSClient implements ObjectStore against Google Cloud Storage.
+type GCSClient struct {
+	client *storage.Client
+}
+
+// NewGCSClient creates a new GCSClient. If credentialsFile is non-empty, it
+// is used as a service-account JSON key; otherwise Application Default
+// Credentials are used.
+func NewGCSClient(ctx context.Context, credentialsFile string) (*GCSClient, error) {
+	var opts []option.ClientOption
+	if credentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(credentialsFile))
+	}
+
+	client, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+
+	return &GCSClient{client: client}, nil
+}
+
+// Upload writes the file at localFile to bucket/key.
+func (g *GCSClient) Upload(ctx context.Context, localFile, bucket, key, sbomFormat string) error {
+	logger.Info("Uploading %s SBOM to gs://%s/%s", sbomFormat, bucket, key)
+
+	file, err := os.Open(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	writer := g.client.Bucket(bucket).Object(key).NewWriter(ctx)
+	writer.ContentType = "application/json"
+	writer.Metadata = map[string]string{
+		"format": sbomFormat,
+		"source": "github-action",
+	}
+
+	if _, err := io.Copy(writer, file); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to upload SBOM to GCS: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to finalize GCS upload: %w", err)
+	}
+
+	logger.Success("SBOM uploaded successfully to GCS")
+	return nil
+}
+
+// Download reads bucket/key into localFile.
+func (g *GCSClient) Download(ctx context.Context, bucket, key, localFile string) error {
+	logger.Debug("Downloading gs://%s/%s to %s", bucket, key, localFile)
+
+	reader, err := g.client.Bucket(bucket).Object(key).NewReader(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to download from GCS: %w", err)
+	}
+	defer reader.Close()
+
+	file, err := os.Create(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the keys of all objects in bucket matching prefix.
+func (g *GCSClient) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	logger.Debug("Listing objects in gs://%s with prefix: %s", bucket, prefix)
+
+	var keys []string
+	it := g.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GCS objects: %w", err)
+		}
+		keys = append(keys, attrs.Name)
+	}
+
+	logger.Info("Found %d objects in GCS", len(keys))
+	return keys, nil
+}
+
+// Delete removes the object at bucket/key.
+func (g *GCSClient) Delete(ctx context.Context, bucket, key string) error {
+	logger.Debug("Deleting gs://%s/%s", bucket, key)
+
+	if err := g.client.Bucket(bucket).Object(key).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete GCS object: %w", err)
+	}
+
+	return nil
+}
+
+// Head reports whether the object at bucket/key exists.
+func (g *GCSClient) Head(ctx context.Context, bucket, key string) (bool, error) {
+	_, err := g.client.Bucket(bucket).Object(key).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to head GCS object: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// S3ParquetStore implements Store by writing components to a local Parquet
+// file and uploading it through an existing ObjectStore, rather than
+// talking to S3 directly - this reuses ObjectStore's S3/minio auth, proxy,
+// and CA bundle handling instead of duplicating it.
+type S3ParquetStore struct {
+	objectStore ObjectStore
+	bucket      string
+	prefix      string
+}
+
+// parquetComponentRow is one components row as written to a Parquet file.
+type parquetComponentRow struct {
+	Name              string  `parquet:"name"`
+	Version           string  `parquet:"version"`
+	Source            string  `parquet:"source"`
+	PURL              string  `parquet:"purl"`
+	CPE               string  `parquet:"cpe"`
+	Hashes            string  `parquet:"hashes"`
+	License           string  `parquet:"license"`
+	LicenseExpression string  `parquet:"license_expression"`
+	LicenseType       string  `parquet:"license_type"`
+	ExpressionID      string  `parquet:"expression_id"`
+	SignerIdentity    string  `parquet:"signer_identity"`
+	SignerIssuer      string  `parquet:"signer_issuer"`
+	RekorLogIndex     string  `parquet:"rekor_log_index"`
+	Vulnerabilities   string  `parquet:"vulnerabilities"`
+	MaxCVSS           float32 `parquet:"max_cvss"`
+	SBOMDigest        string  `parquet:"sbom_digest"`
+}
+
+// NewS3ParquetStore creates a new S3ParquetStore that writes Parquet files
+// under cfg.S3Bucket/cfg.ParquetPrefix via objectStore.
+func NewS3ParquetStore(cfg *config.Config, objectStore ObjectStore) (*S3ParquetStore, error) {
+	if cfg.S3Bucket == "" {
+		return nil, fmt.Errorf("S3_BUCKET is required for the s3parquet components backend")
+	}
+
+	return &S3ParquetStore{
+		objectStore: objectStore,
+		bucket:      cfg.S3Bucket,
+		prefix:      cfg.ParquetPrefix,
+	}, nil
+}
+
+// SetupTable is a no-op: Parquet files are schemaless-on-write and queried
+// externally (e.g. via ClickHouse's s3() function, Athena, or DuckDB), so
+// there is no table to create ahead of time.
+func (s *S3ParquetStore) SetupTable(ctx context.Context, tableName string) error {
+	return nil
+}
+
+// InsertComponents drains components into a local Parquet file compressed
+// with Snappy, then uploads it to
+// s3://bucket/prefix/date=YYYY-MM-DD/run=<sbom_digest>.parquet.
+func (s *S3ParquetStore) InsertComponents(ctx context.Context, tableName string, run RunMetadata, components <-chan Component) error {
+	tmpFile, err := os.CreateTemp("", "clickbom-parquet-*.parquet")
+	if err != nil {
+		return fmt.Errorf("failed to create temp Parquet file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	writer := parquet.NewGenericWriter[parquetComponentRow](tmpFile, parquet.Compression(&parquet.Snappy))
+
+	var rowCount int
+	for comp := range components {
+		vulnJSON, err := vulnerabilitiesJSON(comp.Vulnerabilities)
+		if err != nil {
+			tmpFile.Close()
+			return fmt.Errorf("failed to marshal vulnerabilities: %w", err)
+		}
+
+		row := parquetComponentRow{
+			Name:              comp.Name,
+			Version:           comp.Version,
+			Source:            comp.Source,
+			PURL:              comp.PURL,
+			CPE:               comp.CPE,
+			Hashes:            comp.Hashes,
+			License:           comp.License,
+			LicenseExpression: comp.LicenseExpression,
+			LicenseType:       comp.LicenseType,
+			ExpressionID:      comp.ExpressionID,
+			SignerIdentity:    comp.SignerIdentity,
+			SignerIssuer:      comp.SignerIssuer,
+			RekorLogIndex:     comp.RekorLogIndex,
+			Vulnerabilities:   string(vulnJSON),
+			MaxCVSS:           comp.MaxCVSS,
+			SBOMDigest:        run.SBOMDigest,
+		}
+
+		if _, err := writer.Write([]parquetComponentRow{row}); err != nil {
+			tmpFile.Close()
+			return fmt.Errorf("failed to write Parquet row: %w", err)
+		}
+		rowCount++
+	}
+
+	if err := writer.Close(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to finalize Parquet file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temp Parquet file: %w", err)
+	}
+
+	key := filepath.ToSlash(filepath.Join(s.prefix,
+		fmt.Sprintf("date=%s", time.Now().UTC().Format("2006-01-02")),
+		fmt.Sprintf("run=%s.parquet", run.SBOMDigest)))
+
+	if err := s.objectStore.Upload(ctx, tmpPath, s.bucket, key, "parquet"); err != nil {
+		return fmt.Errorf("failed to upload Parquet file to s3://%s/%s: %w", s.bucket, key, err)
+	}
+
+	logger.Success("Uploaded %d components to s3://%s/%s", rowCount, s.bucket, key)
+	return nil
+}
+
+// Close is a no-op: the underlying ObjectStore's lifecycle is owned by the
+// caller that constructed it.
+func (s *S3ParquetStore) Close() error {
+	return nil
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// PostgresStore implements Store against a Postgres (or Postgres-compatible)
+// database, using pgx's COPY protocol for bulk inserts.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresStore creates a new PostgresStore, connecting to the database
+// identified by cfg.PostgresDSN.
+func NewPostgresStore(ctx context.Context, cfg *config.Config) (*PostgresStore, error) {
+	pool, err := pgxpool.New(ctx, cfg.PostgresDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Postgres connection pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to connect to Postgres: %w", err)
+	}
+
+	return &PostgresStore{pool: pool}, nil
+}
+
+// SetupTable creates tableName if it does not already exist, with a column
+// set equivalent to the ClickHouse components table. Vulnerabilities are
+// stored as a jsonb column rather than a normalized table, since Postgres
+// has no native equivalent of ClickHouse's Array(Tuple(...)).
+func (p *PostgresStore) SetupTable(ctx context.Context, tableName string) error {
+	ddl := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		name               TEXT NOT NULL,
+		version            TEXT NOT NULL DEFAULT '',
+		source             TEXT NOT NULL DEFAULT '',
+		purl               TEXT NOT NULL DEFAULT '',
+		cpe                TEXT NOT NULL DEFAULT '',
+		hashes             TEXT NOT NULL DEFAULT '',
+		license            TEXT NOT NULL DEFAULT '',
+		license_expression TEXT NOT NULL DEFAULT '',
+		license_type       TEXT NOT NULL DEFAULT '',
+		expression_id      TEXT NOT NULL DEFAULT '',
+		signer_identity    TEXT NOT NULL DEFAULT '',
+		signer_issuer      TEXT NOT NULL DEFAULT '',
+		rekor_log_index    TEXT NOT NULL DEFAULT '',
+		vulnerabilities    JSONB NOT NULL DEFAULT '[]',
+		max_cvss           REAL NOT NULL DEFAULT 0,
+		sbom_digest        TEXT NOT NULL DEFAULT '',
+		inserted_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`, pgx.Identifier{tableName}.Sanitize())
+
+	if _, err := p.pool.Exec(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create Postgres table %s: %w", tableName, err)
+	}
+
+	indexName := tableName + "_digest_idx"
+	indexDDL := fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s ON %s (sbom_digest)`,
+		pgx.Identifier{indexName}.Sanitize(), pgx.Identifier{tableName}.Sanitize())
+	if _, err := p.pool.Exec(ctx, indexDDL); err != nil {
+		return fmt.Errorf("failed to create Postgres index on %s: %w", tableName, err)
+	}
+
+	return nil
+}
+
+// InsertComponents drains components into tableName using pgx's CopyFrom,
+// then records a scan run row in tableName + "_scan_runs".
+func (p *PostgresStore) InsertComponents(ctx context.Context, tableName string, run RunMetadata, components <-chan Component) error {
+	columns := []string{
+		"name", "version", "source", "purl", "cpe", "hashes",
+		"license", "license_expression", "license_type", "expression_id",
+		"signer_identity", "signer_issuer", "rekor_log_index",
+		"vulnerabilities", "max_cvss", "sbom_digest",
+	}
+
+	var rowCount int
+	rows := pgx.CopyFromFunc(func() ([]any, error) {
+		comp, ok := <-components
+		if !ok {
+			return nil, nil
+		}
+		rowCount++
+		vulnJSON, err := vulnerabilitiesJSON(comp.Vulnerabilities)
+		if err != nil {
+			return nil, err
+		}
+		return []any{
+			comp.Name, comp.Version, comp.Source, comp.PURL, comp.CPE, comp.Hashes,
+			comp.License, comp.LicenseExpression, comp.LicenseType, comp.ExpressionID,
+			comp.SignerIdentity, comp.SignerIssuer, comp.RekorLogIndex,
+			vulnJSON, comp.MaxCVSS, run.SBOMDigest,
+		}, nil
+	})
+
+	if _, err := p.pool.CopyFrom(ctx, pgx.Identifier{tableName}, columns, rows); err != nil {
+		return fmt.Errorf("failed to copy components into Postgres table %s: %w", tableName, err)
+	}
+
+	scanRunsTable := scanRunsTableName(tableName)
+	if err := p.setupScanRunsTable(ctx, scanRunsTable); err != nil {
+		return fmt.Errorf("failed to setup scan runs table: %w", err)
+	}
+	if err := p.recordScanRun(ctx, scanRunsTable, run, rowCount); err != nil {
+		logger.Warning("Failed to record scan run metadata: %v", err)
+	}
+
+	return nil
+}
+
+// setupScanRunsTable creates the Postgres equivalent of ClickHouse's
+// scan_runs metadata table.
+func (p *PostgresStore) setupScanRunsTable(ctx context.Context, tableName string) error {
+	ddl := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		sbom_digest      TEXT NOT NULL,
+		source           TEXT NOT NULL DEFAULT '',
+		repository       TEXT NOT NULL DEFAULT '',
+		commit_sha       TEXT NOT NULL DEFAULT '',
+		component_count  INTEGER NOT NULL DEFAULT 0,
+		ingested_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`, pgx.Identifier{tableName}.Sanitize())
+
+	if _, err := p.pool.Exec(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create Postgres scan runs table %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// recordScanRun inserts one row into tableName describing this ingestion.
+func (p *PostgresStore) recordScanRun(ctx context.Context, tableName string, run RunMetadata, componentCount int) error {
+	insert := fmt.Sprintf(`INSERT INTO %s (sbom_digest, source, repository, commit_sha, component_count) VALUES ($1, $2, $3, $4, $5)`,
+		pgx.Identifier{tableName}.Sanitize())
+	_, err := p.pool.Exec(ctx, insert, run.SBOMDigest, run.Source, run.Repository, run.CommitSHA, componentCount)
+	return err
+}
+
+// Close releases the Postgres connection pool.
+func (p *PostgresStore) Close() error {
+	p.pool.Close()
+	return nil
+}
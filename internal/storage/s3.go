@@ -2,39 +2,190 @@ package storage
 
 import (
     "context"
+    "errors"
     "fmt"
+    "io"
+    "net/http"
+    "net/url"
     "os"
-    
+
     "github.com/aws/aws-sdk-go-v2/aws"
     "github.com/aws/aws-sdk-go-v2/config"
     "github.com/aws/aws-sdk-go-v2/credentials"
+    "github.com/aws/aws-sdk-go-v2/credentials/stscreds"
     "github.com/aws/aws-sdk-go-v2/service/s3"
-    
+    "github.com/aws/aws-sdk-go-v2/service/s3/types"
+    "github.com/aws/aws-sdk-go-v2/service/sts"
+
     "github.com/ClickHouse/ClickBOM/pkg/logger"
 )
 
 type S3Client struct {
     client *s3.Client
+
+    sseMode     string
+    sseKMSKeyID string
+    objectTags  map[string]string
+}
+
+// S3AuthConfig carries everything NewS3ClientWithAuth needs to authenticate,
+// across every supported AWS_AUTH_MODE.
+type S3AuthConfig struct {
+    AuthMode        string // "static", "default-chain", "irsa", "assume-role", "web-identity"
+    AccessKeyID     string
+    SecretAccessKey string
+    Region          string
+    EndpointURL     string // S3-compatible endpoint override (e.g. MinIO)
+
+    RoleARN         string
+    ExternalID      string
+    RoleSessionName string
+
+    // HTTPClient, if set, replaces the SDK's default HTTP client so S3
+    // calls honor the module's proxy and custom CA bundle settings.
+    HTTPClient *http.Client
+
+    // SSEMode selects the server-side encryption applied to every upload:
+    // "", "none" (no encryption parameters), "AES256", or "aws:kms".
+    SSEMode string
+    // SSEKMSKeyID is the KMS key ID or ARN used when SSEMode is "aws:kms".
+    // Left empty, S3 encrypts with the bucket's default KMS key.
+    SSEKMSKeyID string
+
+    // ObjectTags are applied to every uploaded object, merged with the
+    // repository/git SHA/SBOM source tags Upload adds automatically.
+    ObjectTags map[string]string
+
+    // Repository, GitSHA, and SBOMSource are applied as "repository",
+    // "git_sha", and "sbom_source" object tags on every upload, so S3
+    // Lifecycle rules, Athena partitions, and cost-allocation reports can
+    // filter by them without parsing object keys.
+    Repository string
+    GitSHA     string
+    SBOMSource string
 }
 
 func NewS3Client(ctx context.Context, accessKeyID, secretAccessKey, region string) (*S3Client, error) {
-    cfg, err := config.LoadDefaultConfig(ctx,
-        config.WithRegion(region),
-        config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
-            accessKeyID,
-            secretAccessKey,
+    return NewS3ClientWithAuth(ctx, S3AuthConfig{
+        AuthMode:        "static",
+        AccessKeyID:     accessKeyID,
+        SecretAccessKey: secretAccessKey,
+        Region:          region,
+    })
+}
+
+// NewS3CompatibleClient creates an S3Client against any S3-compatible
+// endpoint (e.g. a self-hosted MinIO server) by overriding the base
+// endpoint and using path-style addressing when endpointURL is set; an
+// empty endpointURL behaves exactly like NewS3Client against AWS.
+func NewS3CompatibleClient(ctx context.Context, accessKeyID, secretAccessKey, region, endpointURL string) (*S3Client, error) {
+    return NewS3ClientWithAuth(ctx, S3AuthConfig{
+        AuthMode:        "static",
+        AccessKeyID:     accessKeyID,
+        SecretAccessKey: secretAccessKey,
+        Region:          region,
+        EndpointURL:     endpointURL,
+    })
+}
+
+// NewS3ClientWithAuth creates an S3Client using the credential strategy
+// selected by authCfg.AuthMode:
+//   - "static" (default): the long-lived AccessKeyID/SecretAccessKey pair
+//   - "default-chain", "irsa": no credentials provider override; the AWS
+//     SDK's default chain picks up EC2/ECS/EKS instance role credentials
+//     or an EKS IRSA web identity token (AWS_WEB_IDENTITY_TOKEN_FILE plus
+//     AWS_ROLE_ARN) on its own
+//   - "web-identity": same as "irsa" - the default chain already handles
+//     AWS_WEB_IDENTITY_TOKEN_FILE/AWS_ROLE_ARN, kept as a distinct mode
+//     name for operators coming from GitHub Actions' OIDC documentation
+//   - "assume-role": calls sts:AssumeRole against RoleARN, using the
+//     default chain's credentials as the caller identity
+//
+// This lets the action run against AWS from GitHub's OIDC provider
+// without ever handling long-lived access keys.
+func NewS3ClientWithAuth(ctx context.Context, authCfg S3AuthConfig) (*S3Client, error) {
+    var loadOptFns []func(*config.LoadOptions) error
+    loadOptFns = append(loadOptFns, config.WithRegion(authCfg.Region))
+    if authCfg.HTTPClient != nil {
+        loadOptFns = append(loadOptFns, config.WithHTTPClient(authCfg.HTTPClient))
+    }
+
+    switch authCfg.AuthMode {
+    case "", "static":
+        loadOptFns = append(loadOptFns, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+            authCfg.AccessKeyID,
+            authCfg.SecretAccessKey,
             "",
-        )),
-    )
+        )))
+    case "default-chain", "irsa", "web-identity", "assume-role":
+        // Credentials come from the default chain below; "assume-role"
+        // layers an AssumeRoleProvider on top once it's loaded.
+    default:
+        return nil, fmt.Errorf("unsupported AWS_AUTH_MODE: %s", authCfg.AuthMode)
+    }
+
+    cfg, err := config.LoadDefaultConfig(ctx, loadOptFns...)
     if err != nil {
         return nil, fmt.Errorf("failed to load AWS config: %w", err)
     }
-    
+
+    if authCfg.AuthMode == "assume-role" {
+        if authCfg.RoleARN == "" {
+            return nil, fmt.Errorf("AWS_ROLE_ARN is required for AWS_AUTH_MODE=assume-role")
+        }
+
+        stsClient := sts.NewFromConfig(cfg)
+        provider := stscreds.NewAssumeRoleProvider(stsClient, authCfg.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+            if authCfg.ExternalID != "" {
+                o.ExternalID = aws.String(authCfg.ExternalID)
+            }
+            if authCfg.RoleSessionName != "" {
+                o.RoleSessionName = authCfg.RoleSessionName
+            }
+        })
+        cfg.Credentials = aws.NewCredentialsCache(provider)
+    }
+
+    var optFns []func(*s3.Options)
+    if authCfg.EndpointURL != "" {
+        optFns = append(optFns, func(o *s3.Options) {
+            o.BaseEndpoint = aws.String(authCfg.EndpointURL)
+            o.UsePathStyle = true
+        })
+    }
+
     return &S3Client{
-        client: s3.NewFromConfig(cfg),
+        client:      s3.NewFromConfig(cfg, optFns...),
+        sseMode:     authCfg.SSEMode,
+        sseKMSKeyID: authCfg.SSEKMSKeyID,
+        objectTags:  mergeObjectTags(authCfg.ObjectTags, authCfg.Repository, authCfg.GitSHA, authCfg.SBOMSource),
     }, nil
 }
 
+// mergeObjectTags combines user-supplied tags with the repository/git
+// SHA/SBOM source tags Upload applies automatically, without mutating the
+// caller's map.
+func mergeObjectTags(tags map[string]string, repository, gitSHA, sbomSource string) map[string]string {
+    merged := make(map[string]string, len(tags)+3)
+    for k, v := range tags {
+        merged[k] = v
+    }
+    if repository != "" {
+        merged["repository"] = repository
+    }
+    if gitSHA != "" {
+        merged["git_sha"] = gitSHA
+    }
+    if sbomSource != "" {
+        merged["sbom_source"] = sbomSource
+    }
+
+    if len(merged) == 0 {
+        return nil
+    }
+    return merged
+}
+
 func (s *S3Client) Upload(ctx context.Context, localFile, bucket, key, sbomFormat string) error {
     logger.Info("Uploading %s SBOM to s3://%s/%s", sbomFormat, bucket, key)
     
@@ -45,8 +196,8 @@ func (s *S3Client) Upload(ctx context.Context, localFile, bucket, key, sbomForma
     defer file.Close()
     
     contentType := "application/json"
-    
-    _, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+
+    input := &s3.PutObjectInput{
         Bucket:      aws.String(bucket),
         Key:         aws.String(key),
         Body:        file,
@@ -55,8 +206,26 @@ func (s *S3Client) Upload(ctx context.Context, localFile, bucket, key, sbomForma
             "format": sbomFormat,
             "source": "github-action",
         },
-    })
-    
+    }
+
+    switch s.sseMode {
+    case "", "none":
+        // No server-side encryption parameters.
+    case "AES256":
+        input.ServerSideEncryption = types.ServerSideEncryptionAes256
+    case "aws:kms":
+        input.ServerSideEncryption = types.ServerSideEncryptionAwsKms
+        if s.sseKMSKeyID != "" {
+            input.SSEKMSKeyId = aws.String(s.sseKMSKeyID)
+        }
+    }
+
+    if len(s.objectTags) > 0 {
+        input.Tagging = aws.String(buildTagging(s.objectTags))
+    }
+
+    _, err = s.client.PutObject(ctx, input)
+
     if err != nil {
         return fmt.Errorf("failed to upload SBOM to S3: %w", err)
     }
@@ -65,6 +234,16 @@ func (s *S3Client) Upload(ctx context.Context, localFile, bucket, key, sbomForma
     return nil
 }
 
+// buildTagging encodes tags as the URL-encoded "key=value&..." query string
+// the S3 PutObject Tagging parameter expects.
+func buildTagging(tags map[string]string) string {
+    values := url.Values{}
+    for k, v := range tags {
+        values.Set(k, v)
+    }
+    return values.Encode()
+}
+
 func (s *S3Client) Download(ctx context.Context, bucket, key, localFile string) error {
     logger.Debug("Downloading s3://%s/%s to %s", bucket, key, localFile)
     
@@ -91,27 +270,61 @@ func (s *S3Client) Download(ctx context.Context, bucket, key, localFile string)
     return nil
 }
 
-func (s *S3Client) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+// List returns the keys of all objects in bucket matching prefix. It
+// satisfies the ObjectStore interface.
+func (s *S3Client) List(ctx context.Context, bucket, prefix string) ([]string, error) {
     logger.Debug("Listing objects in s3://%s with prefix: %s", bucket, prefix)
-    
+
     var keys []string
-    
+
     paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
         Bucket: aws.String(bucket),
         Prefix: aws.String(prefix),
     })
-    
+
     for paginator.HasMorePages() {
         page, err := paginator.NextPage(ctx)
         if err != nil {
             return nil, fmt.Errorf("failed to list S3 objects: %w", err)
         }
-        
+
         for _, obj := range page.Contents {
             keys = append(keys, *obj.Key)
         }
     }
-    
+
     logger.Info("Found %d objects in S3", len(keys))
     return keys, nil
 }
+
+// Delete removes the object at bucket/key.
+func (s *S3Client) Delete(ctx context.Context, bucket, key string) error {
+    logger.Debug("Deleting s3://%s/%s", bucket, key)
+
+    _, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+        Bucket: aws.String(bucket),
+        Key:    aws.String(key),
+    })
+    if err != nil {
+        return fmt.Errorf("failed to delete S3 object: %w", err)
+    }
+
+    return nil
+}
+
+// Head reports whether the object at bucket/key exists.
+func (s *S3Client) Head(ctx context.Context, bucket, key string) (bool, error) {
+    _, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+        Bucket: aws.String(bucket),
+        Key:    aws.String(key),
+    })
+    if err != nil {
+        var notFound *types.NotFound
+        if errors.As(err, &notFound) {
+            return false, nil
+        }
+        return false, fmt.Errorf("failed to head S3 object: %w", err)
+    }
+
+    return true, nil
+}
@@ -0,0 +1,343 @@
+package storage
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// SnapshotMetadata is the JSON sidecar stored alongside every snapshot. It
+// exists both so users can query SBOM history without downloading the
+// (possibly compressed) SBOM itself, and so PruneSnapshots can recover each
+// snapshot's true creation time - S3 LastModified changes on re-upload and
+// would misorder retention.
+type SnapshotMetadata struct {
+	Source         string    `json:"source"`
+	Format         string    `json:"format"`
+	ComponentCount int       `json:"component_count"`
+	SHA256         string    `json:"sha256"`
+	CreatedAt      time.Time `json:"created_at"`
+	Repo           string    `json:"repo"`
+	GitSHA         string    `json:"git_sha"`
+	// SnapshotKey is the object key of the SBOM this sidecar describes, so
+	// PruneSnapshots can delete it without guessing its compression suffix.
+	SnapshotKey string `json:"snapshot_key"`
+}
+
+// SnapshotManager uploads time-partitioned, optionally compressed SBOM
+// snapshots with a JSON metadata sidecar, and retains only the most recent
+// N of them. It is the count-based, queryable-history counterpart to
+// ArchiveManager, which prunes by age and/or count.
+type SnapshotManager struct {
+	store ObjectStore
+}
+
+// NewSnapshotManager creates a SnapshotManager backed by the given ObjectStore.
+func NewSnapshotManager(store ObjectStore) *SnapshotManager {
+	return &SnapshotManager{store: store}
+}
+
+// UploadSnapshot uploads localFile under a YYYY/MM/DD-partitioned key within
+// prefix, named "<repo>-<gitSHA>-<unix timestamp>.json", optionally
+// compressed per compression ("gzip", "zip", or "" / "none" for no
+// compression; ".gz" or ".zip" is then appended to the name), and drops a
+// "<prefix>/.metadata/<same name>.json" sidecar for it. The sidecar is not
+// placed in the date partition next to the snapshot.
+func (m *SnapshotManager) UploadSnapshot(ctx context.Context, localFile, bucket, prefix, sbomFormat, compression, repo, gitSHA string) error {
+	logger.Info("Uploading SBOM snapshot under s3://%s/%s", bucket, prefix)
+
+	digest, err := sha256File(localFile)
+	if err != nil {
+		return fmt.Errorf("failed to hash snapshot: %w", err)
+	}
+
+	componentCount, err := countComponents(localFile, sbomFormat)
+	if err != nil {
+		logger.Warning("Failed to count components for snapshot metadata: %v", err)
+	}
+
+	payloadFile, ext, err := compressSnapshot(localFile, compression)
+	if err != nil {
+		return fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	if payloadFile != localFile {
+		defer os.Remove(payloadFile)
+	}
+
+	now := time.Now().UTC()
+	basename := fmt.Sprintf("%s-%s-%d", sanitizeKeyComponent(repo), sanitizeKeyComponent(gitSHA), now.Unix())
+	key := path.Join(strings.Trim(prefix, "/"), now.Format("2006/01/02"), basename+ext)
+
+	if err := m.store.Upload(ctx, payloadFile, bucket, key, sbomFormat); err != nil {
+		return fmt.Errorf("failed to upload snapshot: %w", err)
+	}
+
+	meta := SnapshotMetadata{
+		Source:         "clickbom",
+		Format:         sbomFormat,
+		ComponentCount: componentCount,
+		SHA256:         digest,
+		CreatedAt:      now,
+		Repo:           repo,
+		GitSHA:         gitSHA,
+		SnapshotKey:    key,
+	}
+	metaFile, err := writeMetadataFile(meta)
+	if err != nil {
+		return fmt.Errorf("failed to write metadata sidecar: %w", err)
+	}
+	defer os.Remove(metaFile)
+
+	metaKey := path.Join(strings.Trim(prefix, "/"), ".metadata", basename+".json")
+	if err := m.store.Upload(ctx, metaFile, bucket, metaKey, "json"); err != nil {
+		return fmt.Errorf("failed to upload metadata sidecar: %w", err)
+	}
+
+	logger.Success("Uploaded SBOM snapshot to s3://%s/%s", bucket, key)
+	return nil
+}
+
+// PruneSnapshots keeps the retention most recent snapshots under prefix,
+// ordered by each snapshot's metadata sidecar created_at, and deletes the
+// rest along with their sidecars. retention <= 0 disables pruning.
+func (m *SnapshotManager) PruneSnapshots(ctx context.Context, bucket, prefix string, retention int) error {
+	if retention <= 0 {
+		return nil
+	}
+
+	metaPrefix := path.Join(strings.Trim(prefix, "/"), ".metadata") + "/"
+	metaKeys, err := m.store.List(ctx, bucket, metaPrefix)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshot metadata: %w", err)
+	}
+
+	type indexedSnapshot struct {
+		metaKey string
+		meta    SnapshotMetadata
+	}
+
+	var snapshots []indexedSnapshot
+	for _, metaKey := range metaKeys {
+		meta, err := m.downloadMetadata(ctx, bucket, metaKey)
+		if err != nil {
+			logger.Warning("Failed to read snapshot metadata %s: %v", metaKey, err)
+			continue
+		}
+		snapshots = append(snapshots, indexedSnapshot{metaKey: metaKey, meta: meta})
+	}
+
+	if len(snapshots) <= retention {
+		return nil
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].meta.CreatedAt.After(snapshots[j].meta.CreatedAt)
+	})
+
+	var pruned int
+	for _, s := range snapshots[retention:] {
+		if s.meta.SnapshotKey != "" {
+			if err := m.store.Delete(ctx, bucket, s.meta.SnapshotKey); err != nil {
+				logger.Warning("Failed to delete expired snapshot %s: %v", s.meta.SnapshotKey, err)
+			}
+		}
+		if err := m.store.Delete(ctx, bucket, s.metaKey); err != nil {
+			logger.Warning("Failed to delete expired snapshot metadata %s: %v", s.metaKey, err)
+			continue
+		}
+		pruned++
+	}
+
+	if pruned > 0 {
+		logger.Info("Pruned %d expired snapshot(s) under %s", pruned, prefix)
+	}
+
+	return nil
+}
+
+// downloadMetadata fetches and decodes the metadata sidecar at metaKey.
+func (m *SnapshotManager) downloadMetadata(ctx context.Context, bucket, metaKey string) (SnapshotMetadata, error) {
+	tmp, err := os.CreateTemp("", "clickbom-snapshot-meta-*.json")
+	if err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := m.store.Download(ctx, bucket, metaKey, tmp.Name()); err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("failed to download metadata: %w", err)
+	}
+
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	var meta SnapshotMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return SnapshotMetadata{}, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// writeMetadataFile serializes meta to a new temp file and returns its path.
+func writeMetadataFile(meta SnapshotMetadata) (string, error) {
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "clickbom-snapshot-meta-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	if _, err := tmp.Write(data); err != nil {
+		return "", fmt.Errorf("failed to write metadata: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// compressSnapshot compresses inputFile per compression ("gzip" or "zip"),
+// returning the path to a new temp file and the extension to append to the
+// uploaded key. compression of "" or "none" returns inputFile unchanged
+// with a plain ".json" extension.
+func compressSnapshot(inputFile, compression string) (path, ext string, err error) {
+	switch compression {
+	case "", "none":
+		return inputFile, ".json", nil
+	case "gzip":
+		out, err := compressGzip(inputFile)
+		return out, ".json.gz", err
+	case "zip":
+		out, err := compressZip(inputFile)
+		return out, ".json.zip", err
+	default:
+		return "", "", fmt.Errorf("unsupported SBOM_COMPRESSION: %s", compression)
+	}
+}
+
+func compressGzip(inputFile string) (string, error) {
+	in, err := os.Open(inputFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp("", "clickbom-snapshot-*.json.gz")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		return "", fmt.Errorf("failed to compress file: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize compression: %w", err)
+	}
+
+	return out.Name(), nil
+}
+
+func compressZip(inputFile string) (string, error) {
+	in, err := os.Open(inputFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to open input file: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.CreateTemp("", "clickbom-snapshot-*.json.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer out.Close()
+
+	zw := zip.NewWriter(out)
+	entry, err := zw.Create("sbom.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create zip entry: %w", err)
+	}
+	if _, err := io.Copy(entry, in); err != nil {
+		return "", fmt.Errorf("failed to compress file: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", fmt.Errorf("failed to finalize zip archive: %w", err)
+	}
+
+	return out.Name(), nil
+}
+
+// sha256File returns the hex-encoded SHA-256 digest of filename's contents.
+func sha256File(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// countComponents reads the component/package count out of a CycloneDX or
+// SPDX JSON document without fully decoding it, mirroring the lightweight
+// parsing internal/sbom/score uses for the same purpose.
+func countComponents(sbomFile, sbomFormat string) (int, error) {
+	data, err := os.ReadFile(sbomFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read SBOM: %w", err)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return 0, fmt.Errorf("failed to parse SBOM: %w", err)
+	}
+
+	key := "components"
+	if sbomFormat == "spdxjson" {
+		key = "packages"
+	}
+
+	raw, ok := doc[key]
+	if !ok {
+		return 0, nil
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return 0, fmt.Errorf("failed to parse %s array: %w", key, err)
+	}
+
+	return len(items), nil
+}
+
+// sanitizeKeyComponentPattern matches everything but alphanumerics, dots,
+// dashes, and underscores, so repo/SHA values can't smuggle path segments
+// into an S3 key.
+var sanitizeKeyComponentPattern = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// sanitizeKeyComponent makes s safe to use as a single path segment of an
+// S3 object key.
+func sanitizeKeyComponent(s string) string {
+	s = sanitizeKeyComponentPattern.ReplaceAllString(s, "-")
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
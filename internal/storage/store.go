@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ClickHouse/ClickBOM/internal/config"
+	"github.com/ClickHouse/ClickBOM/internal/sbom/model"
+)
+
+// Store is the common interface implemented by every supported components
+// storage backend (ClickHouse, Postgres, BigQuery, S3 Parquet). It decouples
+// InsertSBOMData's ingestion pipeline from any one warehouse.
+type Store interface {
+	// SetupTable prepares tableName (or its backend-specific equivalent) for
+	// insertion, creating it and applying any pending migrations if needed.
+	SetupTable(ctx context.Context, tableName string) error
+	// InsertComponents drains components into tableName, associating every
+	// row with run. Callers close the channel to signal the end of the
+	// batch; InsertComponents returns once it has been fully drained.
+	InsertComponents(ctx context.Context, tableName string, run RunMetadata, components <-chan Component) error
+	// Close releases any connections or clients held by the Store.
+	Close() error
+}
+
+// NewStore constructs the Store for the given backend ("clickhouse"
+// (default), "postgres", "bigquery", or "s3parquet"). objectStore is used by
+// the "s3parquet" backend to upload the Parquet files it writes; it may be
+// nil for the other backends.
+func NewStore(ctx context.Context, backend string, cfg *config.Config, objectStore ObjectStore) (Store, error) {
+	switch backend {
+	case "", "clickhouse":
+		return NewClickHouseClient(cfg)
+	case "postgres":
+		return NewPostgresStore(ctx, cfg)
+	case "bigquery":
+		return NewBigQueryStore(ctx, cfg)
+	case "s3parquet":
+		if objectStore == nil {
+			return nil, fmt.Errorf("storage: no object storage backend configured for the s3parquet components backend")
+		}
+		return NewS3ParquetStore(cfg, objectStore)
+	default:
+		return nil, fmt.Errorf("unsupported components backend: %s", backend)
+	}
+}
+
+// RunMetadata identifies the SBOM ingestion run a batch of Components was
+// extracted from.
+type RunMetadata struct {
+	ScanMetadata
+	// SBOMDigest is the SHA-256 digest of the source SBOM file.
+	SBOMDigest string
+}
+
+// Component is one SBOM component as written to a Store, backend-agnostic
+// and independent of internal/sbom/model.Component.
+type Component struct {
+	Name, Version, Source, PURL, CPE, Hashes string
+	License                                  string
+	LicenseExpression                        string
+	LicenseType                               string
+	ExpressionID                              string
+	SignerIdentity                            string
+	SignerIssuer                              string
+	RekorLogIndex                             string
+	Vulnerabilities                           []Vulnerability
+	MaxCVSS                                   float32
+}
+
+// Vulnerability is one vulnerability matched against a Component, mirroring
+// pkg/enrich.Vulnerability without depending on it directly.
+type Vulnerability struct {
+	ID           string
+	CVSS         float32
+	Severity     string
+	FixedVersion string
+}
+
+// ComponentFromModel converts a parsed SBOM component into the
+// backend-agnostic row type non-ClickHouse Store implementations consume.
+// The SBOM digest is not carried on Component itself - callers pass it
+// once per batch via RunMetadata.SBOMDigest instead. Unlike ClickHouse's
+// buildRows, it does not split compound SPDX license expressions into
+// multiple rows, nor attach signer identity or vulnerability enrichment -
+// those remain ClickHouse-specific capabilities layered on top of
+// InsertSBOMData.
+func ComponentFromModel(comp model.Component) Component {
+	name := comp.Name
+	if name == "" {
+		name = "unknown"
+	}
+	version := comp.Version
+	if version == "" {
+		version = "unknown"
+	}
+	source := comp.Properties["source"]
+	if source == "" {
+		source = "unknown"
+	}
+
+	var hashes string
+	for algo, value := range comp.Hashes {
+		if hashes != "" {
+			hashes += ","
+		}
+		hashes += fmt.Sprintf("%s:%s", algo, value)
+	}
+
+	license := "unknown"
+	if len(comp.Licenses) > 0 {
+		license = comp.Licenses[0]
+	}
+
+	return Component{
+		Name:    name,
+		Version: version,
+		Source:  source,
+		PURL:    comp.PURL,
+		CPE:     comp.CPE,
+		Hashes:  hashes,
+		License: license,
+	}
+}
+
+// vulnerabilitiesJSON marshals vulns for storage in a backend's JSON/JSONB
+// column, used by backends (Postgres, BigQuery) that have no native
+// equivalent of ClickHouse's Array(Tuple(...)) column type.
+func vulnerabilitiesJSON(vulns []Vulnerability) ([]byte, error) {
+	if len(vulns) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(vulns)
+}
+
+// ObjectStore is the common interface implemented by every supported object
+// storage backend (S3, MinIO, GCS, Azure Blob, local file). It lets handleNormalMode and merge
+// mode operate against any configured cloud without branching on provider.
+type ObjectStore interface {
+	// Upload writes the file at localFile to bucket/key, tagging it with sbomFormat.
+	Upload(ctx context.Context, localFile, bucket, key, sbomFormat string) error
+	// Download reads bucket/key into localFile.
+	Download(ctx context.Context, bucket, key, localFile string) error
+	// List returns the keys of objects in bucket matching prefix.
+	List(ctx context.Context, bucket, prefix string) ([]string, error)
+	// Delete removes the object at bucket/key.
+	Delete(ctx context.Context, bucket, key string) error
+	// Head reports whether the object at bucket/key exists.
+	Head(ctx context.Context, bucket, key string) (bool, error)
+}
+
+// NewObjectStore constructs the ObjectStore for the given backend
+// ("s3", "minio", "gcs", "azblob", or "file"), defaulting to S3 when
+// backend is empty.
+func NewObjectStore(ctx context.Context, backend string, cfg ObjectStoreConfig) (ObjectStore, error) {
+	switch backend {
+	case "", "s3":
+		return NewS3ClientWithAuth(ctx, cfg.s3AuthConfig(""))
+	case "minio":
+		return NewS3ClientWithAuth(ctx, cfg.s3AuthConfig(cfg.S3EndpointURL))
+	case "gcs":
+		return NewGCSClient(ctx, cfg.GCSCredentialsFile)
+	case "azblob":
+		return NewAzureBlobClient(ctx, cfg.AzureStorageAccount, cfg.AzureSASToken, cfg.AzureSharedKey)
+	case "file":
+		return NewFileObjectStore(cfg.FileBaseDir)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", backend)
+	}
+}
+
+// ObjectStoreConfig carries the subset of config.Config needed to construct
+// any of the supported ObjectStore backends.
+type ObjectStoreConfig struct {
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSRegion          string
+
+	// AWSAuthMode selects the credential strategy for the "s3"/"minio"
+	// backends: "static", "default-chain", "irsa", "assume-role", or
+	// "web-identity". See NewS3ClientWithAuth for details.
+	AWSAuthMode        string
+	AWSRoleARN         string
+	AWSExternalID      string
+	AWSRoleSessionName string
+
+	// S3EndpointURL overrides the default AWS endpoint for the "minio"
+	// backend (or any other S3-compatible service).
+	S3EndpointURL string
+
+	// HTTPClient, if set, is used for the "s3"/"minio" backends instead of
+	// the AWS SDK's default client, so they honor the module's proxy and
+	// custom CA bundle settings.
+	HTTPClient *http.Client
+
+	// S3SSEMode selects server-side encryption for the "s3"/"minio"
+	// backends: "", "none", "AES256", or "aws:kms".
+	S3SSEMode     string
+	S3SSEKMSKeyID string
+	S3ObjectTags  map[string]string
+	S3Repository  string
+	S3GitSHA      string
+	S3SBOMSource  string
+
+	GCSCredentialsFile string
+
+	AzureStorageAccount string
+	AzureSASToken       string
+	AzureSharedKey      string
+
+	// FileBaseDir is the root directory used by the "file" backend.
+	FileBaseDir string
+}
+
+// s3AuthConfig builds the S3AuthConfig used to construct the "s3" and
+// "minio" backends, overriding the endpoint URL for S3-compatible services.
+func (cfg ObjectStoreConfig) s3AuthConfig(endpointURL string) S3AuthConfig {
+	return S3AuthConfig{
+		AuthMode:        cfg.AWSAuthMode,
+		AccessKeyID:     cfg.AWSAccessKeyID,
+		SecretAccessKey: cfg.AWSSecretAccessKey,
+		Region:          cfg.AWSRegion,
+		EndpointURL:     endpointURL,
+		RoleARN:         cfg.AWSRoleARN,
+		ExternalID:      cfg.AWSExternalID,
+		RoleSessionName: cfg.AWSRoleSessionName,
+		HTTPClient:      cfg.HTTPClient,
+		SSEMode:         cfg.S3SSEMode,
+		SSEKMSKeyID:     cfg.S3SSEKMSKeyID,
+		ObjectTags:      cfg.S3ObjectTags,
+		Repository:      cfg.S3Repository,
+		GitSHA:          cfg.S3GitSHA,
+		SBOMSource:      cfg.S3SBOMSource,
+	}
+}
@@ -4,6 +4,7 @@ package validation
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"unicode"
 )
@@ -189,6 +190,60 @@ func SanitizePatterns(patterns string) string {
 	return strings.Join(sanitized, ",")
 }
 
+// SanitizeTags parses a comma-separated "key=value" list (e.g. S3 object
+// tags), stripping dangerous characters from each key and value and
+// dropping any entry that isn't a valid "key=value" pair.
+func SanitizeTags(tags string) map[string]string {
+	if tags == "" {
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, pair := range strings.Split(tags, ",") {
+		pair = strings.TrimSpace(pair)
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+
+		key = removeChars(strings.TrimSpace(key), `[^a-zA-Z0-9._:/-]`)
+		value = removeChars(strings.TrimSpace(value), `[^a-zA-Z0-9._:/-]`)
+		if key == "" || value == "" {
+			continue
+		}
+
+		result[key] = value
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// SanitizeTagsString cleans a comma-separated "key=value" list the same
+// way SanitizeTags does, but returns the cleaned, deterministically
+// ordered string form rather than a map - for sanitizing a config field
+// in place, where the caller still wants a string.
+func SanitizeTagsString(tags string) string {
+	parsed := SanitizeTags(tags)
+	if len(parsed) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+parsed[key])
+	}
+	return strings.Join(pairs, ",")
+}
+
 func removeChars(s, pattern string) string {
 	re := regexp.MustCompile(pattern)
 	return re.ReplaceAllString(s, "")
@@ -156,3 +156,36 @@ func TestSanitizeString(t *testing.T) {
         })
     }
 }
+
+func TestSanitizeTagsString(t *testing.T) {
+    tests := []struct {
+        name  string
+        input string
+        want  string
+    }{
+        {
+            name:  "empty input",
+            input: "",
+            want:  "",
+        },
+        {
+            name:  "sorts and strips dangerous characters",
+            input: "env=prod;rm, team=data$",
+            want:  "env=prodrm,team=data",
+        },
+        {
+            name:  "drops malformed pairs",
+            input: "no-equals-sign, key=value",
+            want:  "key=value",
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got := SanitizeTagsString(tt.input)
+            if got != tt.want {
+                t.Errorf("SanitizeTagsString() = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
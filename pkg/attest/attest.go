@@ -0,0 +1,265 @@
+// Package attest verifies cryptographic signatures and in-toto attestations
+// over an SBOM before it is trusted for ingestion. Verification is
+// delegated to the cosign CLI, which performs the underlying
+// Sigstore/Fulcio certificate and Rekor transparency log checks; this
+// package parses cosign's output and the signing certificate to surface
+// signer identity for storage alongside the ingested SBOM.
+package attest
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Config controls how a signature or attestation is verified.
+type Config struct {
+	// PublicKeyPath, when set, verifies against a static cosign public key
+	// (mirroring the key-based signing in sbom.WrapInTotoStatement) instead
+	// of keyless/Fulcio verification.
+	PublicKeyPath string
+	// AllowedIdentities restricts verification to certificates whose
+	// identity (the SAN Fulcio embeds, e.g. a GitHub Actions job's
+	// "https://github.com/owner/repo/.github/workflows/ci.yml@refs/heads/main")
+	// matches one of these patterns. A trailing "*" matches as a prefix.
+	// Ignored for key-based verification. Empty means any identity cosign
+	// itself accepts is trusted.
+	AllowedIdentities []string
+	// AllowedIssuer is the expected OIDC issuer for keyless verification,
+	// e.g. "https://token.actions.githubusercontent.com". Required unless
+	// PublicKeyPath is set.
+	AllowedIssuer string
+	// RekorURL overrides cosign's default Rekor transparency log endpoint.
+	RekorURL string
+}
+
+// Result is the outcome of a successful verification: the verified SBOM
+// bytes plus metadata about who signed it. SignerIdentity, SignerIssuer,
+// and CertFingerprint are empty for key-based verification, which has no
+// certificate to inspect.
+type Result struct {
+	SBOM            []byte
+	SignerIdentity  string
+	SignerIssuer    string
+	CertFingerprint string
+	RekorLogIndex   string
+}
+
+// VerifyBlob verifies a detached cosign signature (sigPath), optionally
+// alongside its signing certificate (certPath, required for keyless
+// verification), over sbomFile.
+func VerifyBlob(ctx context.Context, sbomFile, sigPath, certPath string, cfg Config) (*Result, error) {
+	extra, err := verifyArgs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCertForIdentityCheck(certPath, cfg); err != nil {
+		return nil, err
+	}
+
+	args := append([]string{"verify-blob", "--signature", sigPath}, extra...)
+	if certPath != "" {
+		args = append(args, "--certificate", certPath)
+	}
+	if cfg.RekorURL != "" {
+		args = append(args, "--rekor-url", cfg.RekorURL)
+	}
+	args = append(args, sbomFile)
+
+	output, err := runCosign(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("attest: signature verification failed: %w", err)
+	}
+
+	return finishResult(sbomFile, certPath, cfg, output)
+}
+
+// VerifyAttestation verifies an in-toto DSSE attestation (attestationPath),
+// as produced by sbom.WrapInTotoStatement, whose predicate is expected to
+// be sbomFile.
+func VerifyAttestation(ctx context.Context, sbomFile, attestationPath, certPath string, cfg Config) (*Result, error) {
+	extra, err := verifyArgs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireCertForIdentityCheck(certPath, cfg); err != nil {
+		return nil, err
+	}
+
+	args := append([]string{"verify-blob-attestation", "--signature", attestationPath}, extra...)
+	if certPath != "" {
+		args = append(args, "--certificate", certPath)
+	}
+	if cfg.RekorURL != "" {
+		args = append(args, "--rekor-url", cfg.RekorURL)
+	}
+	args = append(args, sbomFile)
+
+	output, err := runCosign(ctx, args)
+	if err != nil {
+		return nil, fmt.Errorf("attest: attestation verification failed: %w", err)
+	}
+
+	return finishResult(sbomFile, certPath, cfg, output)
+}
+
+// verifyArgs returns the cosign flags selecting key-based or keyless
+// verification, per cfg.
+func verifyArgs(cfg Config) ([]string, error) {
+	if cfg.PublicKeyPath != "" {
+		return []string{"--key", cfg.PublicKeyPath}, nil
+	}
+	if cfg.AllowedIssuer == "" {
+		return nil, fmt.Errorf("attest: AllowedIssuer is required for keyless verification")
+	}
+	// The identity allow-list is enforced ourselves in inspectCertificate,
+	// so cosign only needs a permissive identity regexp to proceed.
+	return []string{"--certificate-identity-regexp", ".*", "--certificate-oidc-issuer", cfg.AllowedIssuer}, nil
+}
+
+// requireCertForIdentityCheck fails closed when keyless verification is
+// configured with an identity allow-list but no certificate path was
+// supplied: without a certificate to inspect, finishResult skips
+// inspectCertificate entirely, cosign itself doesn't require
+// --certificate when the bundle carries its own, and the SBOM would come
+// back "verified" with AllowedIdentities silently never enforced.
+func requireCertForIdentityCheck(certPath string, cfg Config) error {
+	if cfg.PublicKeyPath != "" || certPath != "" {
+		return nil
+	}
+	if len(cfg.AllowedIdentities) > 0 {
+		return fmt.Errorf("attest: AllowedIdentities is configured but no certificate path was supplied to inspect it against")
+	}
+	return nil
+}
+
+// finishResult inspects the signing certificate (if any) and reads back
+// sbomFile to build a Result once cosign has confirmed the signature itself
+// is valid.
+func finishResult(sbomFile, certPath string, cfg Config, cosignOutput string) (*Result, error) {
+	result := &Result{RekorLogIndex: extractRekorLogIndex(cosignOutput)}
+
+	if certPath != "" {
+		identity, issuer, fingerprint, err := inspectCertificate(certPath, cfg.AllowedIdentities)
+		if err != nil {
+			return nil, fmt.Errorf("attest: failed to inspect signing certificate: %w", err)
+		}
+		result.SignerIdentity = identity
+		result.SignerIssuer = issuer
+		result.CertFingerprint = fingerprint
+	}
+
+	data, err := os.ReadFile(sbomFile)
+	if err != nil {
+		return nil, fmt.Errorf("attest: failed to read verified SBOM: %w", err)
+	}
+	result.SBOM = data
+
+	return result, nil
+}
+
+func runCosign(ctx context.Context, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, "cosign", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("cosign %s: %w\nOutput: %s", args[0], err, string(output))
+	}
+	return string(output), nil
+}
+
+// fulcioIssuerOID is the X.509 extension Fulcio embeds the signing
+// identity's OIDC issuer under.
+// See https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md.
+var fulcioIssuerOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+
+// inspectCertificate parses the PEM-encoded certificate at certPath and
+// returns its signer identity, OIDC issuer, and SHA-256 fingerprint,
+// rejecting it if allowed is non-empty and the identity doesn't match.
+func inspectCertificate(certPath string, allowed []string) (identity, issuer, fingerprint string, err error) {
+	pemBytes, err := os.ReadFile(certPath)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return "", "", "", fmt.Errorf("no PEM block found in certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	identity = certificateIdentity(cert)
+	if len(allowed) > 0 && !identityAllowed(identity, allowed) {
+		return "", "", "", fmt.Errorf("signer identity %q is not in the allow-list", identity)
+	}
+
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(fulcioIssuerOID) {
+			issuer = string(ext.Value)
+			break
+		}
+	}
+
+	sum := sha256.Sum256(block.Bytes)
+	fingerprint = hex.EncodeToString(sum[:])
+
+	return identity, issuer, fingerprint, nil
+}
+
+// certificateIdentity returns the SAN cosign itself treats as a Fulcio
+// certificate's identity: the first URI SAN (how GitHub Actions' OIDC
+// identity is encoded), falling back to the first email or DNS SAN.
+func certificateIdentity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return ""
+}
+
+// identityAllowed reports whether identity matches one of the allow-list
+// patterns, where a trailing "*" matches as a prefix (e.g.
+// "repo:owner/repo:ref:*").
+func identityAllowed(identity string, allowed []string) bool {
+	for _, pattern := range allowed {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(identity, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+			continue
+		}
+		if identity == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+var rekorLogIndexPattern = regexp.MustCompile(`(?i)tlog entry verified.*index:?\s*(\d+)`)
+
+// extractRekorLogIndex pulls the Rekor transparency log index out of
+// cosign's verification output, or "" if cosign didn't report one (e.g.
+// key-based verification without a transparency log entry).
+func extractRekorLogIndex(cosignOutput string) string {
+	m := rekorLogIndexPattern.FindStringSubmatch(cosignOutput)
+	if len(m) < 2 {
+		return ""
+	}
+	return m[1]
+}
@@ -0,0 +1,82 @@
+package attest
+
+import "testing"
+
+func TestIdentityAllowed(t *testing.T) {
+	allowed := []string{"repo:owner/repo:ref:refs/heads/main", "repo:owner/other:ref:*"}
+
+	cases := map[string]bool{
+		"repo:owner/repo:ref:refs/heads/main":  true,
+		"repo:owner/other:ref:refs/heads/any":  true,
+		"repo:owner/repo:ref:refs/heads/other": false,
+		"repo:someone-else/repo:ref:x":         false,
+	}
+
+	for identity, want := range cases {
+		if got := identityAllowed(identity, allowed); got != want {
+			t.Errorf("identityAllowed(%q) = %v, want %v", identity, got, want)
+		}
+	}
+}
+
+func TestIdentityAllowedEmptyAllowList(t *testing.T) {
+	if identityAllowed("anything", nil) {
+		t.Error("expected no identity to match an empty allow-list")
+	}
+}
+
+func TestExtractRekorLogIndex(t *testing.T) {
+	output := "Verification for sbom.json --\ntlog entry verified with uuid: \"abc123\" index: 12345\n"
+	if got := extractRekorLogIndex(output); got != "12345" {
+		t.Errorf("extractRekorLogIndex() = %q, want %q", got, "12345")
+	}
+}
+
+func TestExtractRekorLogIndexMissing(t *testing.T) {
+	if got := extractRekorLogIndex("no tlog entry here"); got != "" {
+		t.Errorf("extractRekorLogIndex() = %q, want empty", got)
+	}
+}
+
+func TestRequireCertForIdentityCheck(t *testing.T) {
+	cases := []struct {
+		name     string
+		certPath string
+		cfg      Config
+		wantErr  bool
+	}{
+		{
+			name:     "keyless with identities but no certificate",
+			certPath: "",
+			cfg:      Config{AllowedIdentities: []string{"repo:owner/repo:ref:refs/heads/main"}},
+			wantErr:  true,
+		},
+		{
+			name:     "keyless with identities and a certificate",
+			certPath: "/tmp/sbom.crt",
+			cfg:      Config{AllowedIdentities: []string{"repo:owner/repo:ref:refs/heads/main"}},
+			wantErr:  false,
+		},
+		{
+			name:     "keyless with no identities configured",
+			certPath: "",
+			cfg:      Config{},
+			wantErr:  false,
+		},
+		{
+			name:     "key-based verification never needs a certificate",
+			certPath: "",
+			cfg:      Config{PublicKeyPath: "/tmp/cosign.pub", AllowedIdentities: []string{"anything"}},
+			wantErr:  false,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requireCertForIdentityCheck(tt.certPath, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requireCertForIdentityCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package enrich
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// cacheGet returns the cached record for id from c.cfg.CacheDir, if caching
+// is enabled, the file exists, and it is younger than c.cfg.CacheTTL.
+func (c *Client) cacheGet(id string) (osvVuln, bool) {
+	if c.cfg.CacheDir == "" {
+		return osvVuln{}, false
+	}
+
+	path := c.cachePath(id)
+	info, err := os.Stat(path)
+	if err != nil {
+		return osvVuln{}, false
+	}
+	if c.cfg.CacheTTL > 0 && time.Since(info.ModTime()) > c.cfg.CacheTTL {
+		return osvVuln{}, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return osvVuln{}, false
+	}
+
+	var vuln osvVuln
+	if err := json.Unmarshal(data, &vuln); err != nil {
+		return osvVuln{}, false
+	}
+
+	return vuln, true
+}
+
+// cachePut writes vuln to disk under c.cfg.CacheDir, if caching is enabled.
+// Write failures are not fatal - the cache is a best-effort optimization.
+func (c *Client) cachePut(id string, vuln osvVuln) {
+	if c.cfg.CacheDir == "" {
+		return
+	}
+	if err := os.MkdirAll(c.cfg.CacheDir, 0755); err != nil {
+		return
+	}
+
+	data, err := json.Marshal(vuln)
+	if err != nil {
+		return
+	}
+
+	_ = os.WriteFile(c.cachePath(id), data, 0644)
+}
+
+func (c *Client) cachePath(id string) string {
+	return filepath.Join(c.cfg.CacheDir, id+".json")
+}
@@ -0,0 +1,82 @@
+package enrich
+
+import (
+	"math"
+	"strings"
+)
+
+// cvssV3MetricWeights are the CVSS v3.0/3.1 base-metric numeric weights,
+// per https://www.first.org/cvss/v3.1/specification-document#Metric-Values.
+var cvssV3MetricWeights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"UI": {"N": 0.85, "R": 0.62},
+	"C":  {"N": 0, "L": 0.22, "H": 0.56},
+	"I":  {"N": 0, "L": 0.22, "H": 0.56},
+	"A":  {"N": 0, "L": 0.22, "H": 0.56},
+}
+
+// cvssV3PRWeights is PR's weight table, which depends on S (Scope).
+var cvssV3PRWeights = map[string]map[string]float64{
+	"U": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"C": {"N": 0.85, "L": 0.68, "H": 0.5},
+}
+
+// parseCVSSV3Vector computes the CVSS v3.0/3.1 base score from a vector
+// string such as "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", per the
+// base-score formula in the CVSS v3.1 specification. Returns 0 if vector
+// is missing a required metric.
+func parseCVSSV3Vector(vector string) float32 {
+	metrics := make(map[string]string)
+	for _, part := range strings.Split(vector, "/") {
+		key, value, found := strings.Cut(part, ":")
+		if found {
+			metrics[key] = value
+		}
+	}
+
+	scope := metrics["S"]
+	av, ok1 := cvssV3MetricWeights["AV"][metrics["AV"]]
+	ac, ok2 := cvssV3MetricWeights["AC"][metrics["AC"]]
+	ui, ok3 := cvssV3MetricWeights["UI"][metrics["UI"]]
+	c, ok4 := cvssV3MetricWeights["C"][metrics["C"]]
+	i, ok5 := cvssV3MetricWeights["I"][metrics["I"]]
+	a, ok6 := cvssV3MetricWeights["A"][metrics["A"]]
+	prWeights, ok7 := cvssV3PRWeights[scope]
+	if !ok7 {
+		return 0
+	}
+	pr, ok8 := prWeights[metrics["PR"]]
+	if !ok1 || !ok2 || !ok3 || !ok4 || !ok5 || !ok6 || !ok8 {
+		return 0
+	}
+
+	iss := 1 - (1-c)*(1-i)*(1-a)
+
+	var impact float64
+	if scope == "U" {
+		impact = 6.42 * iss
+	} else {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0
+	}
+
+	exploitability := 8.22 * av * ac * pr * ui
+
+	var base float64
+	if scope == "U" {
+		base = math.Min(impact+exploitability, 10)
+	} else {
+		base = math.Min(1.08*(impact+exploitability), 10)
+	}
+
+	return float32(roundUpToTenth(base))
+}
+
+// roundUpToTenth implements the CVSS spec's Roundup function: round a
+// score up to the nearest 0.1 rather than to the nearest value.
+func roundUpToTenth(score float64) float64 {
+	return math.Ceil(score*10) / 10
+}
@@ -0,0 +1,21 @@
+package enrich
+
+import "testing"
+
+func TestParseCVSSV3Vector(t *testing.T) {
+	tests := []struct {
+		vector string
+		want   float32
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0},
+		{"CVSS:3.1/AV:N/AC:L/S:U/C:H/I:H/A:H", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseCVSSV3Vector(tt.vector); got != tt.want {
+			t.Errorf("parseCVSSV3Vector(%q) = %v, want %v", tt.vector, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package enrich
+
+import "strings"
+
+// purlEcosystems maps a package URL type to the ecosystem name OSV expects
+// in its query package.ecosystem field.
+// See https://ossf.github.io/osv-schema/#affectedpackage-field.
+var purlEcosystems = map[string]string{
+	"npm":       "npm",
+	"pypi":      "PyPI",
+	"golang":    "Go",
+	"cargo":     "crates.io",
+	"maven":     "Maven",
+	"nuget":     "NuGet",
+	"gem":       "RubyGems",
+	"composer":  "Packagist",
+	"hex":       "Hex",
+	"pub":       "Pub",
+	"deb":       "Debian",
+	"rpm":       "Red Hat",
+	"apk":       "Alpine",
+	"conan":     "ConanCenter",
+	"swift":     "SwiftURL",
+	"cocoapods": "CocoaPods",
+}
+
+// Package identifies a single component to look up in OSV, by its package
+// URL (e.g. "pkg:npm/left-pad@1.3.0").
+type Package struct {
+	PURL string
+}
+
+// parsedPURL holds the fields of a package URL relevant to an OSV query.
+// Qualifiers and subpath are not needed for vulnerability lookups and are
+// discarded.
+type parsedPURL struct {
+	Type      string
+	Namespace string
+	Name      string
+	Version   string
+}
+
+// parsePURL extracts the type, namespace, name, and version from a package
+// URL of the form "pkg:type/namespace/name@version", per the purl spec
+// (namespace is optional). Qualifiers ("?key=value") and a subpath
+// ("#path") are stripped if present.
+func parsePURL(purl string) (parsedPURL, error) {
+	const prefix = "pkg:"
+	if !strings.HasPrefix(purl, prefix) {
+		return parsedPURL{}, errInvalidPURL(purl)
+	}
+	rest := purl[len(prefix):]
+
+	if idx := strings.IndexAny(rest, "?#"); idx >= 0 {
+		rest = rest[:idx]
+	}
+
+	typeAndPath := strings.SplitN(rest, "/", 2)
+	if len(typeAndPath) != 2 || typeAndPath[0] == "" {
+		return parsedPURL{}, errInvalidPURL(purl)
+	}
+	purlType := typeAndPath[0]
+
+	path := typeAndPath[1]
+	nameAndVersion := path
+	var namespace string
+	if idx := strings.LastIndex(path, "/"); idx >= 0 {
+		namespace = path[:idx]
+		nameAndVersion = path[idx+1:]
+	}
+
+	name := nameAndVersion
+	var version string
+	if idx := strings.LastIndex(nameAndVersion, "@"); idx >= 0 {
+		name = nameAndVersion[:idx]
+		version = nameAndVersion[idx+1:]
+	}
+	if name == "" {
+		return parsedPURL{}, errInvalidPURL(purl)
+	}
+
+	return parsedPURL{Type: purlType, Namespace: namespace, Name: name, Version: version}, nil
+}
+
+func errInvalidPURL(purl string) error {
+	return &invalidPURLError{purl: purl}
+}
+
+type invalidPURLError struct {
+	purl string
+}
+
+func (e *invalidPURLError) Error() string {
+	return "enrich: not a valid package URL: " + e.purl
+}
+
+// osvPackageName returns the package name OSV expects for p.Type: the bare
+// name when p has no namespace, "group:artifact" for Maven, and
+// "namespace/name" for every other type (e.g. Packagist's "vendor/package").
+func osvPackageName(p parsedPURL) string {
+	if p.Namespace == "" {
+		return p.Name
+	}
+	switch p.Type {
+	case "maven":
+		return p.Namespace + ":" + p.Name
+	case "composer", "golang":
+		return p.Namespace + "/" + p.Name
+	default:
+		return p.Namespace + "/" + p.Name
+	}
+}
+
+// EcosystemForPURL returns the OSV ecosystem name for purl's package type
+// (e.g. "npm" for "pkg:npm/left-pad@1.3.0"), and false if the type has no
+// known OSV ecosystem mapping.
+func EcosystemForPURL(purl string) (string, bool) {
+	p, err := parsePURL(purl)
+	if err != nil {
+		return "", false
+	}
+	ecosystem, ok := purlEcosystems[p.Type]
+	return ecosystem, ok
+}
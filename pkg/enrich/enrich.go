@@ -0,0 +1,237 @@
+// Package enrich joins SBOM components against vulnerability databases -
+// primarily OSV.dev, with an offline mode backed by a pre-downloaded OSV
+// export - so each component can be annotated with the vulnerability IDs,
+// CVSS v3.1 scores, severities, fixed versions, and CWE IDs that affect it,
+// before the SBOM is written to storage.
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// Vulnerability is one vulnerability matched against a Package.
+type Vulnerability struct {
+	ID           string
+	CVSS         float32
+	Severity     string
+	FixedVersion string
+	CWEs         []string
+}
+
+// Config controls how a Client looks up and caches vulnerability data.
+type Config struct {
+	// OSVEndpoint is the base URL of the OSV API. Defaults to
+	// "https://api.osv.dev" when empty. Ignored when OfflineZipPath is set.
+	OSVEndpoint string
+	// Concurrency caps how many OSV requests run at once. Defaults to 4
+	// when <= 0.
+	Concurrency int
+	// CacheDir, when set, caches each looked-up vulnerability record on
+	// disk as "<CacheDir>/<id>.json" for CacheTTL, so repeated runs over
+	// the same components don't re-query OSV.
+	CacheDir string
+	CacheTTL time.Duration
+	// OfflineZipPath, when set, loads vulnerabilities from a pre-downloaded
+	// OSV "all.zip"-style export (one JSON vulnerability record per zip
+	// entry) instead of querying the OSV API.
+	OfflineZipPath string
+	// HTTPClient is used for OSV API requests. Defaults to
+	// http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// Client looks up vulnerabilities for a batch of packages.
+type Client struct {
+	cfg Config
+
+	offlineOnce  sync.Once
+	offlineIndex map[string][]osvVuln
+	offlineErr   error
+}
+
+// NewClient returns a Client configured per cfg, filling in defaults for
+// any zero-valued fields.
+func NewClient(cfg Config) *Client {
+	if cfg.OSVEndpoint == "" {
+		cfg.OSVEndpoint = "https://api.osv.dev"
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	return &Client{cfg: cfg}
+}
+
+// Enrich looks up vulnerabilities for each package, keyed by PURL. Packages
+// whose purl has no known OSV ecosystem mapping (see EcosystemForPURL) are
+// skipped and logged, not treated as an error.
+func (c *Client) Enrich(ctx context.Context, packages []Package) (map[string][]Vulnerability, error) {
+	if c.cfg.OfflineZipPath != "" {
+		return c.enrichOffline(packages)
+	}
+	return c.enrichOnline(ctx, packages)
+}
+
+// enrichOnline looks up packages against the live OSV API: querybatch calls
+// of up to 1000 queries each, sent one after another, to find matching
+// vulnerability IDs, then one /v1/vulns/{id} call per distinct ID to get the
+// full record, with up to c.cfg.Concurrency of those in flight at a time.
+func (c *Client) enrichOnline(ctx context.Context, packages []Package) (map[string][]Vulnerability, error) {
+	type lookup struct {
+		purl  string
+		query osvQuery
+	}
+
+	lookups := make([]lookup, 0, len(packages))
+	for _, pkg := range packages {
+		p, err := parsePURL(pkg.PURL)
+		if err != nil {
+			logger.Warning("enrich: skipping %q: %v", pkg.PURL, err)
+			continue
+		}
+		ecosystem, ok := purlEcosystems[p.Type]
+		if !ok {
+			logger.Debug("enrich: no OSV ecosystem mapping for purl type %q, skipping %q", p.Type, pkg.PURL)
+			continue
+		}
+		lookups = append(lookups, lookup{
+			purl: pkg.PURL,
+			query: osvQuery{
+				Version: p.Version,
+				Package: osvQueryPackage{Name: osvPackageName(p), Ecosystem: ecosystem},
+			},
+		})
+	}
+	if len(lookups) == 0 {
+		return map[string][]Vulnerability{}, nil
+	}
+
+	// OSV's querybatch endpoint accepts up to 1000 queries per call.
+	const batchLimit = 1000
+	idsByPURL := make(map[string][]string, len(lookups))
+	allIDs := make(map[string]struct{})
+
+	for start := 0; start < len(lookups); start += batchLimit {
+		end := start + batchLimit
+		if end > len(lookups) {
+			end = len(lookups)
+		}
+		chunk := lookups[start:end]
+
+		queries := make([]osvQuery, len(chunk))
+		for i, l := range chunk {
+			queries[i] = l.query
+		}
+
+		results, err := c.queryBatch(ctx, queries)
+		if err != nil {
+			return nil, err
+		}
+		if len(results) != len(chunk) {
+			logger.Warning("enrich: OSV batch returned %d results for %d queries, skipping mismatched entries", len(results), len(chunk))
+			continue
+		}
+
+		for i, result := range results {
+			ids := make([]string, 0, len(result.Vulns))
+			for _, v := range result.Vulns {
+				ids = append(ids, v.ID)
+				allIDs[v.ID] = struct{}{}
+			}
+			if len(ids) > 0 {
+				idsByPURL[chunk[i].purl] = ids
+			}
+		}
+	}
+
+	vulnsByID, err := c.fetchVulnsConcurrently(ctx, allIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembleResults(idsByPURL, vulnsByID), nil
+}
+
+// fetchVulnsConcurrently fetches the full record for each ID in ids, using
+// up to c.cfg.Concurrency requests at a time.
+func (c *Client) fetchVulnsConcurrently(ctx context.Context, ids map[string]struct{}) (map[string]osvVuln, error) {
+	type result struct {
+		id   string
+		vuln osvVuln
+		err  error
+	}
+
+	idList := make([]string, 0, len(ids))
+	for id := range ids {
+		idList = append(idList, id)
+	}
+
+	results := make(chan result, len(idList))
+	sem := make(chan struct{}, c.cfg.Concurrency)
+	var wg sync.WaitGroup
+
+	for _, id := range idList {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			vuln, err := c.fetchVuln(ctx, id)
+			results <- result{id: id, vuln: vuln, err: err}
+		}(id)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	vulnsByID := make(map[string]osvVuln, len(idList))
+	for r := range results {
+		if r.err != nil {
+			logger.Warning("enrich: failed to fetch OSV vulnerability %s: %v", r.id, r.err)
+			continue
+		}
+		vulnsByID[r.id] = r.vuln
+	}
+
+	return vulnsByID, nil
+}
+
+// assembleResults joins per-package vulnerability IDs with the fetched
+// vulnerability records, dropping any ID whose record couldn't be fetched.
+func assembleResults(idsByPURL map[string][]string, vulnsByID map[string]osvVuln) map[string][]Vulnerability {
+	out := make(map[string][]Vulnerability, len(idsByPURL))
+	for purl, ids := range idsByPURL {
+		vulns := make([]Vulnerability, 0, len(ids))
+		for _, id := range ids {
+			if vuln, ok := vulnsByID[id]; ok {
+				vulns = append(vulns, vuln.toVulnerability())
+			}
+		}
+		if len(vulns) > 0 {
+			out[purl] = vulns
+		}
+	}
+	return out
+}
+
+// MaxCVSS returns the highest CVSS score among vulns, or 0 if vulns is
+// empty.
+func MaxCVSS(vulns []Vulnerability) float32 {
+	var max float32
+	for _, v := range vulns {
+		if v.CVSS > max {
+			max = v.CVSS
+		}
+	}
+	return max
+}
@@ -0,0 +1,93 @@
+package enrich
+
+import "testing"
+
+func TestParsePURL(t *testing.T) {
+	tests := []struct {
+		purl    string
+		want    parsedPURL
+		wantErr bool
+	}{
+		{
+			purl: "pkg:npm/left-pad@1.3.0",
+			want: parsedPURL{Type: "npm", Name: "left-pad", Version: "1.3.0"},
+		},
+		{
+			purl: "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+			want: parsedPURL{Type: "maven", Namespace: "org.apache.commons", Name: "commons-lang3", Version: "3.12.0"},
+		},
+		{
+			purl: "pkg:golang/github.com/pkg/errors@v0.9.1",
+			want: parsedPURL{Type: "golang", Namespace: "github.com/pkg", Name: "errors", Version: "v0.9.1"},
+		},
+		{
+			purl: "pkg:npm/left-pad@1.3.0?arch=x86#sub/path",
+			want: parsedPURL{Type: "npm", Name: "left-pad", Version: "1.3.0"},
+		},
+		{
+			purl:    "not-a-purl",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePURL(tt.purl)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePURL(%q) error = %v, wantErr %v", tt.purl, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePURL(%q) = %+v, want %+v", tt.purl, got, tt.want)
+		}
+	}
+}
+
+func TestEcosystemForPURL(t *testing.T) {
+	tests := map[string]string{
+		"pkg:npm/left-pad@1.3.0":     "npm",
+		"pkg:pypi/requests@2.31.0":   "PyPI",
+		"pkg:golang/github.com/x@v1": "Go",
+		"pkg:cargo/serde@1.0.0":      "crates.io",
+	}
+	for purl, want := range tests {
+		got, ok := EcosystemForPURL(purl)
+		if !ok || got != want {
+			t.Errorf("EcosystemForPURL(%q) = (%q, %v), want (%q, true)", purl, got, ok, want)
+		}
+	}
+
+	if _, ok := EcosystemForPURL("pkg:unknown-type/foo@1.0.0"); ok {
+		t.Error("expected no ecosystem mapping for an unrecognized purl type")
+	}
+}
+
+func TestSeverityForCVSS(t *testing.T) {
+	tests := []struct {
+		score float32
+		want  string
+	}{
+		{9.8, "CRITICAL"},
+		{7.5, "HIGH"},
+		{5.0, "MEDIUM"},
+		{2.0, "LOW"},
+		{0, "NONE"},
+	}
+	for _, tt := range tests {
+		if got := severityForCVSS(tt.score); got != tt.want {
+			t.Errorf("severityForCVSS(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestMaxCVSS(t *testing.T) {
+	vulns := []Vulnerability{{CVSS: 4.5}, {CVSS: 9.1}, {CVSS: 2.0}}
+	if got := MaxCVSS(vulns); got != 9.1 {
+		t.Errorf("MaxCVSS() = %v, want 9.1", got)
+	}
+	if got := MaxCVSS(nil); got != 0 {
+		t.Errorf("MaxCVSS(nil) = %v, want 0", got)
+	}
+}
@@ -0,0 +1,185 @@
+package enrich
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ClickHouse/ClickBOM/pkg/logger"
+)
+
+// enrichOffline looks up packages against c.cfg.OfflineZipPath instead of
+// the OSV API, for environments without outbound network access.
+func (c *Client) enrichOffline(packages []Package) (map[string][]Vulnerability, error) {
+	index, err := c.loadOfflineIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string][]Vulnerability, len(packages))
+	for _, pkg := range packages {
+		p, err := parsePURL(pkg.PURL)
+		if err != nil {
+			logger.Warning("enrich: skipping %q: %v", pkg.PURL, err)
+			continue
+		}
+		ecosystem, ok := purlEcosystems[p.Type]
+		if !ok {
+			logger.Debug("enrich: no OSV ecosystem mapping for purl type %q, skipping %q", p.Type, pkg.PURL)
+			continue
+		}
+
+		vulns, ok := index[ecosystem+"|"+osvPackageName(p)]
+		if !ok {
+			continue
+		}
+
+		matched := make([]Vulnerability, 0, len(vulns))
+		for _, v := range vulns {
+			if affectsVersion(v, p.Version) {
+				matched = append(matched, v.toVulnerability())
+			}
+		}
+		if len(matched) > 0 {
+			out[pkg.PURL] = matched
+		}
+	}
+
+	return out, nil
+}
+
+// affectsVersion reports whether version falls within one of v's affected
+// ranges, or whether version is empty (in which case every known
+// vulnerability for the package is considered a match, since there's
+// nothing more specific to filter on).
+func affectsVersion(v osvVuln, version string) bool {
+	if version == "" {
+		return true
+	}
+	for _, affected := range v.Affected {
+		for _, r := range affected.Ranges {
+			if rangeContainsVersion(r.Events, version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rangeContainsVersion reports whether version falls within any of the
+// intervals events describes. Per the OSV schema, events within a range
+// are sorted ascending and alternate an "introduced" version with
+// whichever of "fixed" (interval's upper bound, exclusive) or
+// "last_affected" (inclusive) closes it; an "introduced" with no closing
+// event before the list ends means every version from there onward is
+// still affected.
+func rangeContainsVersion(events []osvEvent, version string) bool {
+	var introduced string
+	open := false
+
+	for _, e := range events {
+		switch {
+		case e.Introduced != "":
+			introduced = e.Introduced
+			open = true
+		case e.Fixed != "":
+			if open && versionInInterval(introduced, version, e.Fixed, false) {
+				return true
+			}
+			open = false
+		case e.LastAffected != "":
+			if open && versionInInterval(introduced, version, e.LastAffected, true) {
+				return true
+			}
+			open = false
+		}
+	}
+
+	return open && compareVersions(version, introduced) >= 0
+}
+
+// versionInInterval reports whether version lies in [introduced, upper)
+// ("fixed" events) or [introduced, upper] ("last_affected" events,
+// inclusive). introduced of "0" means the range has always affected the
+// package, per OSV convention.
+func versionInInterval(introduced, version, upper string, inclusive bool) bool {
+	if introduced != "0" && compareVersions(version, introduced) < 0 {
+		return false
+	}
+	cmp := compareVersions(version, upper)
+	if inclusive {
+		return cmp <= 0
+	}
+	return cmp < 0
+}
+
+// loadOfflineIndex parses c.cfg.OfflineZipPath once, building an
+// ecosystem+name index of every vulnerability record it contains. OSV's
+// bulk export ("all.zip" per ecosystem, or a combined export) holds one
+// vulnerability per zip entry as a JSON file.
+func (c *Client) loadOfflineIndex() (map[string][]osvVuln, error) {
+	c.offlineOnce.Do(func() {
+		c.offlineIndex, c.offlineErr = readOfflineZip(c.cfg.OfflineZipPath)
+	})
+	return c.offlineIndex, c.offlineErr
+}
+
+func readOfflineZip(path string) (map[string][]osvVuln, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("enrich: failed to open offline OSV export %s: %w", path, err)
+	}
+	defer r.Close()
+
+	index := make(map[string][]osvVuln)
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		vuln, err := readOfflineEntry(f)
+		if err != nil {
+			logger.Warning("enrich: skipping offline OSV entry %s: %v", f.Name, err)
+			continue
+		}
+
+		for _, key := range offlineIndexKeys(vuln) {
+			index[key] = append(index[key], vuln)
+		}
+	}
+
+	return index, nil
+}
+
+// offlineIndexKeys returns the "ecosystem|name" keys vuln should be indexed
+// under, one per affected package it lists.
+func offlineIndexKeys(vuln osvVuln) []string {
+	keys := make([]string, 0, len(vuln.Affected))
+	for _, affected := range vuln.Affected {
+		if affected.Package.Ecosystem == "" || affected.Package.Name == "" {
+			continue
+		}
+		keys = append(keys, affected.Package.Ecosystem+"|"+affected.Package.Name)
+	}
+	return keys
+}
+
+func readOfflineEntry(f *zip.File) (osvVuln, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return osvVuln{}, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return osvVuln{}, err
+	}
+
+	var vuln osvVuln
+	if err := json.Unmarshal(data, &vuln); err != nil {
+		return osvVuln{}, err
+	}
+	return vuln, nil
+}
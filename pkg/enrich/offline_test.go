@@ -0,0 +1,118 @@
+package enrich
+
+import "testing"
+
+func TestAffectsVersionFixedRange(t *testing.T) {
+	v := osvVuln{
+		Affected: []struct {
+			Package struct {
+				Ecosystem string `json:"ecosystem"`
+				Name      string `json:"name"`
+			} `json:"package"`
+			Ranges []struct {
+				Events []osvEvent `json:"events"`
+			} `json:"ranges"`
+		}{
+			{
+				Ranges: []struct {
+					Events []osvEvent `json:"events"`
+				}{
+					{Events: []osvEvent{{Introduced: "0"}, {Fixed: "1.3.0"}}},
+				},
+			},
+		},
+	}
+
+	tests := map[string]bool{
+		"1.0.0": true,
+		"1.2.9": true,
+		"1.3.0": false,
+		"1.4.0": false,
+	}
+	for version, want := range tests {
+		if got := affectsVersion(v, version); got != want {
+			t.Errorf("affectsVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestAffectsVersionLastAffectedInclusive(t *testing.T) {
+	v := osvVuln{
+		Affected: []struct {
+			Package struct {
+				Ecosystem string `json:"ecosystem"`
+				Name      string `json:"name"`
+			} `json:"package"`
+			Ranges []struct {
+				Events []osvEvent `json:"events"`
+			} `json:"ranges"`
+		}{
+			{
+				Ranges: []struct {
+					Events []osvEvent `json:"events"`
+				}{
+					{Events: []osvEvent{{Introduced: "2.0.0"}, {LastAffected: "2.1.0"}}},
+				},
+			},
+		},
+	}
+
+	tests := map[string]bool{
+		"1.9.0": false,
+		"2.0.0": true,
+		"2.1.0": true,
+		"2.2.0": false,
+	}
+	for version, want := range tests {
+		if got := affectsVersion(v, version); got != want {
+			t.Errorf("affectsVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+func TestAffectsVersionOpenEndedRange(t *testing.T) {
+	v := osvVuln{
+		Affected: []struct {
+			Package struct {
+				Ecosystem string `json:"ecosystem"`
+				Name      string `json:"name"`
+			} `json:"package"`
+			Ranges []struct {
+				Events []osvEvent `json:"events"`
+			} `json:"ranges"`
+		}{
+			{
+				Ranges: []struct {
+					Events []osvEvent `json:"events"`
+				}{
+					{Events: []osvEvent{{Introduced: "1.5.0"}}},
+				},
+			},
+		},
+	}
+
+	if affectsVersion(v, "1.4.0") {
+		t.Error("version before introduced should not be affected")
+	}
+	if !affectsVersion(v, "9.9.9") {
+		t.Error("version after an unfixed introduced event should be affected")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"1.10.0", "1.2.0", 1},
+		{"1.0", "1.0.0", 0},
+		{"v1.2.3", "1.2.3", 0},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
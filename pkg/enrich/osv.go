@@ -0,0 +1,207 @@
+package enrich
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// osvQuery is one entry in a /v1/querybatch request.
+type osvQuery struct {
+	Version string          `json:"version,omitempty"`
+	Package osvQueryPackage `json:"package"`
+}
+
+type osvQueryPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvBatchRequest struct {
+	Queries []osvQuery `json:"queries"`
+}
+
+// osvBatchResult is one entry in a /v1/querybatch response: just enough to
+// know which vulnerability IDs matched a query, per OSV's batch API
+// (the full record, including CVSS/severity/fixed versions, requires a
+// follow-up /v1/vulns/{id} lookup).
+type osvBatchResult struct {
+	Vulns []struct {
+		ID string `json:"id"`
+	} `json:"vulns"`
+}
+
+type osvBatchResponse struct {
+	Results []osvBatchResult `json:"results"`
+}
+
+// osvEvent is one entry in an affected range's events list, per the OSV
+// schema: a range is a sorted sequence of these, alternating an
+// "introduced" version with whichever of "fixed" or "last_affected"
+// closes that interval.
+type osvEvent struct {
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"last_affected"`
+}
+
+// osvVuln is the subset of OSV's vulnerability schema this package reads.
+// See https://ossf.github.io/osv-schema/.
+type osvVuln struct {
+	ID       string `json:"id"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Events []osvEvent `json:"events"`
+		} `json:"ranges"`
+	} `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string   `json:"severity"`
+		CWEIDs   []string `json:"cwe_ids"`
+	} `json:"database_specific"`
+}
+
+// toVulnerability converts an OSV vulnerability record into the package's
+// plainer result type.
+func (v osvVuln) toVulnerability() Vulnerability {
+	vuln := Vulnerability{
+		ID:       v.ID,
+		Severity: v.DatabaseSpecific.Severity,
+		CWEs:     v.DatabaseSpecific.CWEIDs,
+	}
+
+	for _, s := range v.Severity {
+		if s.Type == "CVSS_V3" {
+			vuln.CVSS = parseCVSSScore(s.Score)
+			break
+		}
+	}
+	if vuln.Severity == "" {
+		vuln.Severity = severityForCVSS(vuln.CVSS)
+	}
+
+	for _, affected := range v.Affected {
+		for _, r := range affected.Ranges {
+			for _, e := range r.Events {
+				if e.Fixed != "" {
+					vuln.FixedVersion = e.Fixed
+				}
+			}
+		}
+	}
+
+	return vuln
+}
+
+// queryBatch looks up vulnerability IDs for queries via OSV's batch
+// endpoint, returning one result slice per query in the same order.
+func (c *Client) queryBatch(ctx context.Context, queries []osvQuery) ([]osvBatchResult, error) {
+	body, err := json.Marshal(osvBatchRequest{Queries: queries})
+	if err != nil {
+		return nil, fmt.Errorf("enrich: failed to marshal OSV batch request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.OSVEndpoint+"/v1/querybatch", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("enrich: failed to create OSV batch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("enrich: OSV batch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("enrich: OSV batch request failed (status %d): %s", resp.StatusCode, string(respBody))
+	}
+
+	var batchResp osvBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("enrich: failed to parse OSV batch response: %w", err)
+	}
+
+	return batchResp.Results, nil
+}
+
+// fetchVuln retrieves the full vulnerability record for id from OSV,
+// checking the on-disk cache first.
+func (c *Client) fetchVuln(ctx context.Context, id string) (osvVuln, error) {
+	if cached, ok := c.cacheGet(id); ok {
+		return cached, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.OSVEndpoint+"/v1/vulns/"+id, nil)
+	if err != nil {
+		return osvVuln{}, fmt.Errorf("enrich: failed to create OSV vuln request: %w", err)
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return osvVuln{}, fmt.Errorf("enrich: OSV vuln request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return osvVuln{}, fmt.Errorf("enrich: OSV vuln request for %s failed (status %d): %s", id, resp.StatusCode, string(body))
+	}
+
+	var vuln osvVuln
+	if err := json.NewDecoder(resp.Body).Decode(&vuln); err != nil {
+		return osvVuln{}, fmt.Errorf("enrich: failed to parse OSV vuln %s: %w", id, err)
+	}
+
+	c.cachePut(id, vuln)
+	return vuln, nil
+}
+
+// parseCVSSScore extracts the base score from a CVSS score string. OSV's
+// severity.score field for CVSS_V3 is the full vector string (e.g.
+// "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"), not a bare number, so
+// the base score has to be computed from it per the CVSS v3.1 spec. Falls
+// back to treating score as a plain number for any other severity type
+// OSV may report (e.g. a future CVSS_V4 numeric field), returning 0 if
+// it's neither.
+func parseCVSSScore(score string) float32 {
+	trimmed := strings.TrimSpace(score)
+	if strings.HasPrefix(trimmed, "CVSS:3") {
+		return parseCVSSV3Vector(trimmed)
+	}
+
+	var f float32
+	if _, err := fmt.Sscanf(trimmed, "%f", &f); err != nil {
+		return 0
+	}
+	return f
+}
+
+// severityForCVSS maps a CVSS v3.1 base score to its qualitative severity
+// rating, used when OSV doesn't report database_specific.severity directly.
+func severityForCVSS(score float32) string {
+	switch {
+	case score >= 9.0:
+		return "CRITICAL"
+	case score >= 7.0:
+		return "HIGH"
+	case score >= 4.0:
+		return "MEDIUM"
+	case score > 0:
+		return "LOW"
+	default:
+		return "NONE"
+	}
+}
@@ -0,0 +1,59 @@
+package enrich
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestToVulnerabilityParsesCVSSV3Vector(t *testing.T) {
+	const fixture = `{
+		"id": "GHSA-xxxx-yyyy-zzzz",
+		"severity": [
+			{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}
+		],
+		"affected": [
+			{
+				"package": {"ecosystem": "npm", "name": "left-pad"},
+				"ranges": [
+					{"events": [{"fixed": "1.3.0"}]}
+				]
+			}
+		]
+	}`
+
+	var vuln osvVuln
+	if err := json.Unmarshal([]byte(fixture), &vuln); err != nil {
+		t.Fatalf("failed to unmarshal fixture: %v", err)
+	}
+
+	got := vuln.toVulnerability()
+	if got.CVSS == 0 {
+		t.Fatalf("expected a non-zero CVSS score from a CVSS_V3 vector, got %v", got.CVSS)
+	}
+	if got.CVSS != 9.8 {
+		t.Errorf("toVulnerability().CVSS = %v, want 9.8", got.CVSS)
+	}
+	if got.Severity != "CRITICAL" {
+		t.Errorf("toVulnerability().Severity = %q, want %q", got.Severity, "CRITICAL")
+	}
+	if got.FixedVersion != "1.3.0" {
+		t.Errorf("toVulnerability().FixedVersion = %q, want %q", got.FixedVersion, "1.3.0")
+	}
+}
+
+func TestParseCVSSScoreVector(t *testing.T) {
+	tests := []struct {
+		score string
+		want  float32
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8},
+		{"CVSS:3.1/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", 1.8},
+		{"not-a-vector-or-number", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseCVSSScore(tt.score); got != tt.want {
+			t.Errorf("parseCVSSScore(%q) = %v, want %v", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package enrich
+
+import (
+	"strconv"
+	"strings"
+)
+
+// compareVersions compares two package versions component-by-component,
+// returning -1, 0, or 1 as a < b, a == b, or a > b. Components are
+// compared numerically when both sides parse as integers (so "1.10.0"
+// correctly sorts after "1.2.0"), falling back to a lexical comparison
+// for non-numeric components (pre-release tags, ecosystem-specific
+// suffixes, etc). This isn't a full semver implementation - OSV affected
+// ranges span every ecosystem's own versioning scheme, and no single
+// library covers all of them - but it's enough to order the dotted,
+// mostly-numeric versions those ranges actually contain.
+func compareVersions(a, b string) int {
+	as := splitVersion(a)
+	bs := splitVersion(b)
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var ac, bc string
+		if i < len(as) {
+			ac = as[i]
+		}
+		if i < len(bs) {
+			bc = bs[i]
+		}
+		if c := compareVersionComponent(ac, bc); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// splitVersion breaks a version string into its dot/dash/plus-delimited
+// components, e.g. "1.2.3-beta.1" -> ["1", "2", "3", "beta", "1"].
+func splitVersion(v string) []string {
+	v = strings.TrimPrefix(v, "v")
+	return strings.FieldsFunc(v, func(r rune) bool {
+		return r == '.' || r == '-' || r == '+'
+	})
+}
+
+// compareVersionComponent compares a single version component, treating a
+// missing component as numeric 0 so "1.0" == "1".
+func compareVersionComponent(a, b string) int {
+	an, aIsNum := parseComponent(a)
+	bn, bIsNum := parseComponent(b)
+	if aIsNum && bIsNum {
+		switch {
+		case an < bn:
+			return -1
+		case an > bn:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+func parseComponent(s string) (uint64, bool) {
+	if s == "" {
+		return 0, true
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
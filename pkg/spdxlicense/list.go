@@ -0,0 +1,67 @@
+package spdxlicense
+
+// knownLicenseIDs is a curated subset of the SPDX license list
+// (https://spdx.org/licenses/) covering the licenses and exceptions this
+// module sees most often in CycloneDX/SPDX SBOMs. It is intentionally not
+// exhaustive - IsKnownID treats "LicenseRef-*" identifiers as opaque rather
+// than require every custom or rare license to be listed here.
+var knownLicenseIDs = map[string]struct{}{
+	"0BSD":                         {},
+	"AFL-3.0":                      {},
+	"AGPL-3.0-only":                {},
+	"AGPL-3.0-or-later":            {},
+	"Apache-1.1":                   {},
+	"Apache-2.0":                   {},
+	"Artistic-2.0":                 {},
+	"BSD-2-Clause":                 {},
+	"BSD-3-Clause":                 {},
+	"BSD-3-Clause-Clear":           {},
+	"BSD-4-Clause":                 {},
+	"BSL-1.0":                      {},
+	"CC0-1.0":                      {},
+	"CC-BY-3.0":                    {},
+	"CC-BY-4.0":                    {},
+	"CC-BY-SA-4.0":                 {},
+	"CDDL-1.0":                     {},
+	"CDDL-1.1":                     {},
+	"CECILL-2.1":                   {},
+	"EPL-1.0":                      {},
+	"EPL-2.0":                      {},
+	"EUPL-1.1":                     {},
+	"EUPL-1.2":                     {},
+	"GPL-2.0-only":                 {},
+	"GPL-2.0-or-later":             {},
+	"GPL-3.0-only":                 {},
+	"GPL-3.0-or-later":             {},
+	"ISC":                          {},
+	"LGPL-2.0-only":                {},
+	"LGPL-2.0-or-later":            {},
+	"LGPL-2.1-only":                {},
+	"LGPL-2.1-or-later":            {},
+	"LGPL-3.0-only":                {},
+	"LGPL-3.0-or-later":            {},
+	"MIT":                          {},
+	"MIT-0":                        {},
+	"MPL-1.1":                      {},
+	"MPL-2.0":                      {},
+	"MS-PL":                        {},
+	"MS-RL":                        {},
+	"NCSA":                         {},
+	"OFL-1.1":                      {},
+	"OpenSSL":                      {},
+	"PostgreSQL":                   {},
+	"Python-2.0":                   {},
+	"Unicode-DFS-2016":             {},
+	"Unlicense":                    {},
+	"Vim":                          {},
+	"WTFPL":                        {},
+	"X11":                          {},
+	"Zlib":                         {},
+	// Common exception identifiers, used on the right-hand side of WITH.
+	"Classpath-exception-2.0":      {},
+	"GCC-exception-3.1":            {},
+	"LLVM-exception":               {},
+	"OpenSSL-exception":            {},
+	"Linux-syscall-note":           {},
+	"Universal-FOSS-exception-1.0": {},
+}
@@ -0,0 +1,247 @@
+// Package spdxlicense parses SPDX license expressions (as used in SPDX's
+// licenseConcluded/licenseDeclared fields and CycloneDX's licenses[].expression
+// field) into an AST of AND/OR/WITH operators and leaf license IDs, and
+// validates leaf IDs against a curated list of known SPDX license
+// identifiers.
+package spdxlicense
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is an SPDX license expression operator.
+type Op string
+
+const (
+	OpAnd  Op = "AND"
+	OpOr   Op = "OR"
+	OpWith Op = "WITH"
+)
+
+// Node is an SPDX license expression AST node: either a *BinaryExpr, a
+// *WithExpr, or a *License leaf.
+type Node interface {
+	// String renders the node back to its canonical SPDX expression form.
+	String() string
+}
+
+// BinaryExpr is an "A AND B" or "A OR B" node.
+type BinaryExpr struct {
+	Op    Op
+	Left  Node
+	Right Node
+}
+
+func (b *BinaryExpr) String() string {
+	return fmt.Sprintf("(%s %s %s)", b.Left.String(), b.Op, b.Right.String())
+}
+
+// WithExpr is a "License WITH Exception" node.
+type WithExpr struct {
+	License   *License
+	Exception string
+}
+
+func (w *WithExpr) String() string {
+	return fmt.Sprintf("%s WITH %s", w.License.String(), w.Exception)
+}
+
+// License is a leaf SPDX license ID, or a "LicenseRef-*" opaque reference.
+type License struct {
+	ID  string
+	Ref bool // true for "LicenseRef-*" / "DocumentRef-*:LicenseRef-*" identifiers
+}
+
+func (l *License) String() string {
+	return l.ID
+}
+
+// Parse parses an SPDX license expression into an AST. It supports "AND",
+// "OR", "WITH", parenthesized grouping, and "LicenseRef-*" identifiers.
+// Operator precedence, highest to lowest: WITH, AND, OR - matching the SPDX
+// specification.
+func Parse(expression string) (Node, error) {
+	p := &parser{tokens: tokenize(expression)}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("empty license expression")
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q in license expression %q", p.tokens[p.pos], expression)
+	}
+
+	return node, nil
+}
+
+// Leaves walks node and returns every leaf License, in left-to-right order,
+// with duplicates included (callers that need a set can dedupe on ID).
+func Leaves(node Node) []*License {
+	switch n := node.(type) {
+	case *License:
+		return []*License{n}
+	case *WithExpr:
+		return []*License{n.License}
+	case *BinaryExpr:
+		return append(Leaves(n.Left), Leaves(n.Right)...)
+	default:
+		return nil
+	}
+}
+
+// IsKnownID reports whether id is a recognized SPDX license identifier or a
+// "LicenseRef-*"/"DocumentRef-*:LicenseRef-*" opaque reference. The known-ID
+// list is a curated subset of the full SPDX license list covering the
+// licenses this module encounters in practice, not an exhaustive copy of
+// https://spdx.org/licenses/.
+func IsKnownID(id string) bool {
+	if isLicenseRef(id) {
+		return true
+	}
+	_, ok := knownLicenseIDs[id]
+	return ok
+}
+
+func isLicenseRef(id string) bool {
+	if idx := strings.Index(id, ":"); idx >= 0 {
+		// DocumentRef-foo:LicenseRef-bar
+		id = id[idx+1:]
+	}
+	return strings.HasPrefix(id, "LicenseRef-")
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// parseOr handles the lowest-precedence "OR" operator.
+func (p *parser) parseOr() (Node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: OpOr, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd handles "AND", binding tighter than "OR" but looser than "WITH".
+func (p *parser) parseAnd() (Node, error) {
+	left, err := p.parseWith()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "AND") {
+		p.next()
+		right, err := p.parseWith()
+		if err != nil {
+			return nil, err
+		}
+		left = &BinaryExpr{Op: OpAnd, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// parseWith handles "License WITH Exception", the highest-precedence operator.
+func (p *parser) parseWith() (Node, error) {
+	left, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(p.peek(), "WITH") {
+		p.next()
+		exception := p.next()
+		if exception == "" {
+			return nil, fmt.Errorf("expected exception identifier after WITH")
+		}
+		license, ok := left.(*License)
+		if !ok {
+			return nil, fmt.Errorf("WITH must follow a single license, not an expression")
+		}
+		return &WithExpr{License: license, Exception: exception}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAtom() (Node, error) {
+	tok := p.next()
+	if tok == "" {
+		return nil, fmt.Errorf("unexpected end of license expression")
+	}
+
+	if tok == "(" {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("expected closing ')' in license expression")
+		}
+		return node, nil
+	}
+
+	if tok == ")" || strings.EqualFold(tok, "AND") || strings.EqualFold(tok, "OR") || strings.EqualFold(tok, "WITH") {
+		return nil, fmt.Errorf("unexpected token %q in license expression", tok)
+	}
+
+	return &License{ID: tok, Ref: isLicenseRef(tok)}, nil
+}
+
+// tokenize splits an SPDX license expression into identifier and
+// parenthesis tokens.
+func tokenize(expression string) []string {
+	var tokens []string
+	var cur strings.Builder
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range expression {
+		switch {
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
@@ -0,0 +1,70 @@
+package spdxlicense
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantLeaves []string
+		wantErr    bool
+	}{
+		{name: "single license", expression: "MIT", wantLeaves: []string{"MIT"}},
+		{name: "or", expression: "MIT OR Apache-2.0", wantLeaves: []string{"MIT", "Apache-2.0"}},
+		{name: "with exception", expression: "GPL-2.0-only WITH Classpath-exception-2.0", wantLeaves: []string{"GPL-2.0-only"}},
+		{
+			name:       "grouped and/or/with",
+			expression: "(MIT OR Apache-2.0) AND GPL-2.0-only WITH Classpath-exception-2.0",
+			wantLeaves: []string{"MIT", "Apache-2.0", "GPL-2.0-only"},
+		},
+		{name: "license ref", expression: "LicenseRef-MyCompany-Proprietary", wantLeaves: []string{"LicenseRef-MyCompany-Proprietary"}},
+		{name: "empty", expression: "", wantErr: true},
+		{name: "dangling with", expression: "MIT WITH", wantErr: true},
+		{name: "with on expression", expression: "(MIT OR Apache-2.0) WITH Classpath-exception-2.0", wantErr: true},
+		{name: "unbalanced parens", expression: "(MIT OR Apache-2.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := Parse(tt.expression)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = nil error, want error", tt.expression)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.expression, err)
+			}
+
+			leaves := Leaves(node)
+			if len(leaves) != len(tt.wantLeaves) {
+				t.Fatalf("Leaves() = %d leaves, want %d", len(leaves), len(tt.wantLeaves))
+			}
+			for i, l := range leaves {
+				if l.ID != tt.wantLeaves[i] {
+					t.Errorf("Leaves()[%d].ID = %q, want %q", i, l.ID, tt.wantLeaves[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIsKnownID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"MIT", true},
+		{"Apache-2.0", true},
+		{"LicenseRef-MyCompany-Proprietary", true},
+		{"DocumentRef-foo:LicenseRef-bar", true},
+		{"Not-A-Real-License", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsKnownID(tt.id); got != tt.want {
+			t.Errorf("IsKnownID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+}
@@ -115,7 +115,7 @@ func TestEndToEndWorkflow(t *testing.T) {
 			t.Fatalf("Failed to setup ClickHouse table: %v", err)
 		}
 
-		if err := chClient.InsertSBOMData(ctx, convertedSBOM, tableName, "cyclonedx"); err != nil {
+		if err := chClient.InsertSBOMData(ctx, convertedSBOM, tableName, "cyclonedx", storage.ScanMetadata{Source: "github"}); err != nil {
 			t.Fatalf("Failed to insert into ClickHouse: %v", err)
 		}
 